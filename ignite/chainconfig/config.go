@@ -1,12 +1,18 @@
 package chainconfig
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/goccy/go-yaml"
 	"github.com/imdario/mergo"
 
@@ -30,6 +36,7 @@ var (
 
 // DefaultConf 保存默認配置。
 var DefaultConf = Config{
+	Version: LatestVersion,
 	Host: Host{
 		// 在 MacOS 上的 Docker 中時，它僅適用於 0.0.0.0.
 		RPC:     "0.0.0.0:26657",
@@ -56,14 +63,20 @@ var DefaultConf = Config{
 //Config 是用戶給定的配置以進行額外的設置
 // 發球期間。
 type Config struct {
-	Accounts  []Account              `yaml:"accounts"`
-	Validator Validator              `yaml:"validator"`
-	Faucet    Faucet                 `yaml:"faucet"`
-	Client    Client                 `yaml:"client"`
-	Build     Build                  `yaml:"build"`
-	Init      Init                   `yaml:"init"`
-	Genesis   map[string]interface{} `yaml:"genesis"`
-	Host      Host                   `yaml:"host"`
+	Version    int                    `yaml:"version"`
+	Accounts   []Account              `yaml:"accounts"`
+	Validator  Validator              `yaml:"validator"`
+	Validators []Validator            `yaml:"validators"`
+	Faucet     Faucet                 `yaml:"faucet"`
+	Client     Client                 `yaml:"client"`
+	Build      Build                  `yaml:"build"`
+	Init       Init                   `yaml:"init"`
+	Genesis    map[string]interface{} `yaml:"genesis"`
+	Host       Host                   `yaml:"host"`
+
+	// GenesisFixtures 是相對於應用程序根目錄的 JSON 或 YAML 創世數據文件或目錄，
+	// 初始化時按模塊合併到創世的 app_state 中。
+	GenesisFixtures []string `yaml:"genesis_fixtures"`
 }
 
 // AccountByName 按名稱查找帳戶。
@@ -86,6 +99,82 @@ type Account struct {
 
 	// 發行帳戶的鏈外的 RPCAddress。
 	RPCAddress string `yaml:"rpc_address,omitempty"`
+
+	// Vesting 設置時，帳戶的部分代幣在創世中按計劃鎖定。
+	Vesting *Vesting `yaml:"vesting,omitempty"`
+
+	// Module 是模塊帳戶的模塊名，例如 distribution。設置時 coins 會存入
+	// 該模塊帳戶的地址，並且不會為帳戶創建密鑰。
+	Module string `yaml:"module,omitempty"`
+}
+
+const (
+	// VestingDelayed 是在結束時間一次性解鎖全部代幣的鎖倉類型。
+	VestingDelayed = "delayed"
+
+	// VestingContinuous 是從開始時間到結束時間線性解鎖代幣的鎖倉類型。
+	VestingContinuous = "continuous"
+)
+
+// Vesting 保存帳戶的鎖倉計劃。
+// 時間可以是 RFC3339 格式的時間、Unix 時間戳，或相對於鏈初始化時間的時間長度，例如 720h。
+type Vesting struct {
+	// Type 是 delayed 或 continuous，為空時設置了 start_time 則為 continuous，否則為 delayed。
+	Type string `yaml:"type,omitempty"`
+
+	// Coins 是帳戶 coins 中被鎖定的部分。
+	Coins []string `yaml:"coins"`
+
+	StartTime string `yaml:"start_time,omitempty"`
+	EndTime   string `yaml:"end_time"`
+}
+
+// VestingType 返回鎖倉類型，未設置時根據是否有開始時間推斷。
+func (v Vesting) VestingType() string {
+	if v.Type != "" {
+		return v.Type
+	}
+	if v.StartTime != "" {
+		return VestingContinuous
+	}
+	return VestingDelayed
+}
+
+// Times 返回相對於 now 的鎖倉開始和結束 Unix 時間戳，沒有開始時間時 start 為 0。
+func (v Vesting) Times(now time.Time) (start, end int64, err error) {
+	if v.StartTime != "" {
+		if start, err = parseVestingTime(v.StartTime, now); err != nil {
+			return 0, 0, err
+		}
+	}
+	if end, err = parseVestingTime(v.EndTime, now); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseVestingTime 將 RFC3339 時間、Unix 時間戳或相對於 now 的時間長度解析為 Unix 時間戳。
+func parseVestingTime(value string, now time.Time) (int64, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ts, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(d).Unix(), nil
+	}
+	return 0, fmt.Errorf("invalid vesting time %q", value)
+}
+
+// ListValidators 返回鏈要初始化的所有驗證者。
+// 設置了 validators 時返回該列表，第一個驗證者為主節點，
+// 否則返回僅包含 validator 的列表。
+func (c Config) ListValidators() []Validator {
+	if len(c.Validators) > 0 {
+		return c.Validators
+	}
+	return []Validator{c.Validator}
 }
 
 //驗證器保存與驗證器設置相關的信息。
@@ -100,6 +189,36 @@ type Build struct {
 	Binary  string   `yaml:"binary"`
 	LDFlags []string `yaml:"ldflags"`
 	Proto   Proto    `yaml:"proto"`
+	Watch   Watch    `yaml:"watch"`
+	Docker  Docker   `yaml:"docker"`
+}
+
+// Docker 配置 serve 使用 docker 目標時構建和運行二進製文件的工具鏈鏡像。
+type Docker struct {
+	// Dockerfile 是相對於應用程序根目錄的 Dockerfile，用於構建工具鏈鏡像，
+	// 為空時使用應用程序根目錄中的 Dockerfile，不存在時使用默認的 Go 工具鏈鏡像。
+	// 鏡像中需要有 Go 工具鏈。
+	Dockerfile string `yaml:"dockerfile"`
+
+	// Target 是 Dockerfile 中用作工具鏈鏡像的構建階段，例如 base。
+	Target string `yaml:"target"`
+}
+
+// Watch 配置 serve 監視哪些源代碼文件以及如何觸發重新構建。
+type Watch struct {
+	// Include 是相對於應用程序根目錄的 glob 模式，只有匹配的文件的修改會觸發重新構建。
+	// 模式支持使用 "**" 匹配任意層目錄，為空時監視 app、cmd、x、proto 和 third_party 目錄。
+	Include []string `yaml:"include"`
+
+	// Exclude 是被忽略的文件和目錄的 glob 模式，被忽略的目錄不會被遍歷。
+	Exclude []string `yaml:"exclude"`
+
+	// Debounce 是最後一次修改之後等待重新構建的時間，例如 500ms。
+	Debounce string `yaml:"debounce"`
+
+	// Manual 為 true 時修改不會自動觸發重新構建，
+	// 而是在按下回車鍵或進程收到 SIGHUP 信號時重新構建。
+	Manual bool `yaml:"manual"`
 }
 
 // Proto 包含 proto 構建配置。
@@ -161,6 +280,76 @@ type Faucet struct {
 
 	// 水龍頭服務器要監聽的端口號。
 	Port int `yaml:"port"`
+
+	// IPLimit 按客戶端 IP 限制轉賬請求的數量。
+	IPLimit FaucetIPLimit `yaml:"ip_limit"`
+
+	// Challenge 配置客戶端在轉賬前需要完成的挑戰。
+	Challenge FaucetChallenge `yaml:"challenge"`
+
+	// Reconcile 為 true 時，除了水龍頭自己的轉賬賬本，還查詢鏈上的交易事件計算每個地址的已轉賬數量。
+	Reconcile bool `yaml:"reconcile"`
+
+	// AdminToken 是訪問水龍頭管理 API 的令牌，為空時不啟用管理 API。
+	AdminToken string `yaml:"admin_token"`
+
+	// Accounts 是除 Name 之外用於轉移代幣的帳戶，轉賬輪流從這些帳戶發送。
+	Accounts []string `yaml:"accounts"`
+
+	// LowBalance 是水龍頭帳戶餘額的下限，低於它時發出警告，為空時不檢查餘額。
+	LowBalance []string `yaml:"low_balance"`
+
+	// BalanceCheckInterval 是檢查水龍頭帳戶餘額的間隔，例如 1m，默認為 30s。
+	BalanceCheckInterval string `yaml:"balance_check_interval"`
+
+	// Refill 配置在水龍頭帳戶餘額低於 LowBalance 時從金庫帳戶補充代幣。
+	Refill FaucetRefill `yaml:"refill"`
+}
+
+// FaucetRefill 配置從金庫帳戶為餘額不足的水龍頭帳戶補充代幣。
+type FaucetRefill struct {
+	// From 是金庫帳戶的名稱，為空時不補充。
+	From string `yaml:"from"`
+
+	// Coins 是每次補充轉入的代幣，只轉入餘額低於下限的面額。
+	Coins []string `yaml:"coins"`
+}
+
+// FaucetIPLimit 按客戶端 IP 和子網限制水龍頭的轉賬請求數量。
+type FaucetIPLimit struct {
+	// Requests 是每個 IP 在 Window 內可以發送的請求數量，為 0 時不限制。
+	Requests int `yaml:"requests"`
+
+	// SubnetRequests 是每個子網（IPv4 為 /24，IPv6 為 /64）在 Window 內可以發送的請求數量，為 0 時不限制。
+	SubnetRequests int `yaml:"subnet_requests"`
+
+	// Window 是計算請求數量的時間窗口，例如 1h。
+	Window string `yaml:"window"`
+
+	// TrustProxy 使用 X-Forwarded-For 頭中的最後一個地址，即反向代理添加的地址作為客戶端 IP，
+	// 只應在水龍頭直接運行在可信的反向代理之後時啟用。
+	TrustProxy bool `yaml:"trust_proxy"`
+}
+
+// FaucetChallenge 配置客戶端在轉賬前需要完成的挑戰。
+type FaucetChallenge struct {
+	// ProofOfWork 是工作量證明挑戰的難度（哈希的前導零位數），為 0 時不需要工作量證明。
+	ProofOfWork int `yaml:"proof_of_work"`
+
+	// Captcha 配置驗證碼，VerifyURL 為空時不需要驗證碼。
+	Captcha FaucetCaptcha `yaml:"captcha"`
+}
+
+// FaucetCaptcha 配置與 reCAPTCHA、hCaptcha 或 Turnstile 兼容的驗證碼服務。
+type FaucetCaptcha struct {
+	// VerifyURL 是驗證碼服務的 siteverify 地址，例如 https://hcaptcha.com/siteverify。
+	VerifyURL string `yaml:"verify_url"`
+
+	// Secret 是站點的私鑰，建議使用 ${ENV} 從環境變量讀取。
+	Secret string `yaml:"secret"`
+
+	// SiteKey 是前端顯示驗證碼使用的站點公鑰。
+	SiteKey string `yaml:"site_key"`
 }
 
 // Init 用給定的值覆蓋 sdk 配置。
@@ -189,12 +378,46 @@ type Host struct {
 	GRPC    string `yaml:"grpc"`
 	GRPCWeb string `yaml:"grpc-web"`
 	API     string `yaml:"api"`
+
+	// Explorer 是 serve 期間內嵌區塊瀏覽器的監聽地址，留空時不啟動瀏覽器。
+	Explorer string `yaml:"explorer"`
+
+	// Health 是 serve 期間狀態端點的監聽地址，報告構建狀態和節點、API、gRPC 及水龍頭的健康狀況，
+	// 留空時不啟動狀態端點。
+	Health string `yaml:"health"`
 }
 
 // Parse 將 config.yml 解析為 UserConfig。
-func Parse(r io.Reader) (Config, error) {
+// 舊版本的配置在解析前會在內存中遷移到最新版本。
+// overlays 按順序深度合併到 r 之上，後面的覆蓋優先級更高，
+// 合併後值中的 ${ENV} 變量會被替換為環境變量的值。
+func Parse(r io.Reader, overlays ...io.Reader) (Config, error) {
+	raw, err := decodeMigrated(r)
+	if err != nil {
+		return Config{}, err
+	}
+
+	for _, overlay := range overlays {
+		rawOverlay, err := decodeOverlay(overlay)
+		if err != nil {
+			return Config{}, err
+		}
+		raw = deepMerge(raw, rawOverlay)
+	}
+
+	interpolated, err := interpolateEnv(raw)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// JSON 是 YAML 的子集，使用 JSON 編碼可以保留字符串值的原始類型。
+	data, err := json.Marshal(interpolated)
+	if err != nil {
+		return Config{}, err
+	}
+
 	var conf Config
-	if err := yaml.NewDecoder(r).Decode(&conf); err != nil {
+	if err := yaml.Unmarshal(data, &conf); err != nil {
 		return conf, err
 	}
 	if err := mergo.Merge(&conf, DefaultConf); err != nil {
@@ -203,27 +426,242 @@ func Parse(r io.Reader) (Config, error) {
 	return conf, validate(conf)
 }
 
+// decodeMigrated 將 YAML 格式的配置遷移到最新版本並解碼為映射。
+func decodeMigrated(r io.Reader) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, _, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(migrated, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// decodeOverlay 與 decodeMigrated 相同，但空的疊加配置（例如只有註釋的配置檔案）
+// 不會被遷移，而是作為空映射返回，合併時不修改配置。
+func decodeOverlay(r io.Reader) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	return decodeMigrated(bytes.NewReader(data))
+}
+
 // ParseFile 從路徑中解析 config.yml。
+// 如果設置了 ProfileEnvVar 環境變量，則同時合併對應的配置檔案。
 func ParseFile(path string) (Config, error) {
+	return ParseFileWithProfile(path, os.Getenv(ProfileEnvVar))
+}
+
+// ParseFileWithProfile 從路徑中解析 config.yml，並將名為 profile 的配置檔案
+// （例如 config.dev.yml）合併到其上。profile 為空時只解析 config.yml。
+func ParseFileWithProfile(path, profile string) (Config, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return Config{}, nil
 	}
 	defer file.Close()
-	return Parse(file)
+
+	if profile == "" {
+		return Parse(file)
+	}
+
+	profileFile, err := os.Open(ProfileFilePath(path, profile))
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot open config profile %q: %w", profile, err)
+	}
+	defer profileFile.Close()
+
+	return Parse(file, profileFile)
 }
 
 // validate 驗證用戶配置。
 func validate(conf Config) error {
+	if err := validateFaucet(conf.Faucet); err != nil {
+		return err
+	}
 	if len(conf.Accounts) == 0 {
 		return &ValidationError{"at least 1 account is needed"}
 	}
+	for _, account := range conf.Accounts {
+		if err := validateAccount(account); err != nil {
+			return err
+		}
+	}
+	for _, v := range conf.ListValidators() {
+		if account, ok := conf.AccountByName(v.Name); ok && account.Module != "" {
+			return &ValidationError{fmt.Sprintf("validator %q cannot be a module account", v.Name)}
+		}
+	}
+	if len(conf.Validators) > 0 {
+		if conf.Validator.Name != "" {
+			return &ValidationError{"validator and validators cannot be used together"}
+		}
+
+		names := make(map[string]bool)
+		for _, v := range conf.Validators {
+			if v.Name == "" {
+				return &ValidationError{"validator name is required"}
+			}
+			if names[v.Name] {
+				return &ValidationError{fmt.Sprintf("validator %q is defined more than once", v.Name)}
+			}
+			names[v.Name] = true
+		}
+		return nil
+	}
 	if conf.Validator.Name == "" {
 		return &ValidationError{"validator is required"}
 	}
 	return nil
 }
 
+// validateFaucet 驗證水龍頭的 IP 限制、挑戰、帳戶池和補充設置。
+func validateFaucet(faucet Faucet) error {
+	limit := faucet.IPLimit
+	if limit.Requests < 0 || limit.SubnetRequests < 0 {
+		return &ValidationError{"faucet.ip_limit requests cannot be negative"}
+	}
+	if (limit.Requests > 0 || limit.SubnetRequests > 0) && limit.Window == "" {
+		return &ValidationError{"faucet.ip_limit.window is required"}
+	}
+	if limit.Window != "" {
+		if d, err := time.ParseDuration(limit.Window); err != nil || d <= 0 {
+			return &ValidationError{fmt.Sprintf("invalid faucet.ip_limit.window duration %q", limit.Window)}
+		}
+	}
+
+	challenge := faucet.Challenge
+	if challenge.ProofOfWork < 0 || challenge.ProofOfWork > 256 {
+		return &ValidationError{"faucet.challenge.proof_of_work must be between 0 and 256"}
+	}
+	if challenge.Captcha.VerifyURL != "" && challenge.Captcha.Secret == "" {
+		return &ValidationError{"faucet.challenge.captcha.secret is required"}
+	}
+
+	accounts := make(map[string]bool)
+	if faucet.Name != nil {
+		accounts[*faucet.Name] = true
+	}
+	for _, account := range faucet.Accounts {
+		if account == "" {
+			return &ValidationError{"faucet.accounts cannot have an empty account name"}
+		}
+		if accounts[account] {
+			return &ValidationError{fmt.Sprintf("duplicated faucet account %q", account)}
+		}
+		accounts[account] = true
+	}
+	if faucet.BalanceCheckInterval != "" {
+		if d, err := time.ParseDuration(faucet.BalanceCheckInterval); err != nil || d <= 0 {
+			return &ValidationError{fmt.Sprintf("invalid faucet.balance_check_interval duration %q", faucet.BalanceCheckInterval)}
+		}
+	}
+	if faucet.Refill.From != "" {
+		// 從水龍頭帳戶通過命令行轉出代幣會使進程內簽名管理的序列號失去同步。
+		if accounts[faucet.Refill.From] {
+			return &ValidationError{fmt.Sprintf("faucet.refill.from %q cannot be a faucet account", faucet.Refill.From)}
+		}
+		if len(faucet.LowBalance) == 0 {
+			return &ValidationError{"faucet.low_balance is required to refill the faucet accounts"}
+		}
+		if len(faucet.Refill.Coins) == 0 {
+			return &ValidationError{"faucet.refill.coins is required"}
+		}
+	}
+	return nil
+}
+
+// holdingsCheckedModules 是啟動時檢查餘額與自己的狀態是否相等的模塊帳戶，
+// 存入代幣會使鏈啟動失敗。distribution 也檢查餘額，但存入的代幣會加入其社區池。
+var holdingsCheckedModules = map[string]bool{
+	"gov":                    true,
+	"bonded_tokens_pool":     true,
+	"not_bonded_tokens_pool": true,
+}
+
+// validateAccount 驗證帳戶的模塊帳戶和鎖倉設置。
+func validateAccount(account Account) error {
+	if account.Module != "" {
+		if account.Mnemonic != "" || account.Address != "" {
+			return &ValidationError{fmt.Sprintf("module account %q cannot have a mnemonic or an address", account.Name)}
+		}
+		if account.Vesting != nil {
+			return &ValidationError{fmt.Sprintf("module account %q cannot have vesting", account.Name)}
+		}
+		if holdingsCheckedModules[account.Module] {
+			return &ValidationError{fmt.Sprintf("module account %q cannot fund module %q, which checks its balance against its own state", account.Name, account.Module)}
+		}
+	}
+
+	v := account.Vesting
+	if v == nil {
+		return nil
+	}
+
+	invalid := func(format string, args ...interface{}) error {
+		return &ValidationError{fmt.Sprintf("account %q vesting: %s", account.Name, fmt.Sprintf(format, args...))}
+	}
+
+	if v.EndTime == "" {
+		return invalid("end_time is required")
+	}
+	switch v.VestingType() {
+	case VestingDelayed:
+		if v.StartTime != "" {
+			return invalid("start_time cannot be used with delayed vesting")
+		}
+	case VestingContinuous:
+		if v.StartTime == "" {
+			return invalid("start_time is required for continuous vesting")
+		}
+	default:
+		return invalid("unknown type %q, expected %s or %s", v.Type, VestingDelayed, VestingContinuous)
+	}
+
+	start, end, err := v.Times(time.Now())
+	if err != nil {
+		return invalid("%s", err)
+	}
+	if v.StartTime != "" && start >= end {
+		return invalid("start_time must be before end_time")
+	}
+
+	if len(v.Coins) == 0 {
+		return invalid("coins are required")
+	}
+	vestingCoins, err := sdk.ParseCoinsNormalized(strings.Join(v.Coins, ","))
+	if err != nil {
+		return invalid("invalid coins: %s", err)
+	}
+	coins, err := sdk.ParseCoinsNormalized(strings.Join(account.Coins, ","))
+	if err != nil {
+		return &ValidationError{fmt.Sprintf("account %q: invalid coins: %s", account.Name, err)}
+	}
+	if !vestingCoins.IsAllLTE(coins) {
+		return invalid("coins %s exceed the account coins %s", vestingCoins, coins)
+	}
+
+	return nil
+}
+
 // 當配置無效時返回 ValidationError。
 type ValidationError struct {
 	Message string
@@ -1,8 +1,10 @@
 package chainconfig
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -139,3 +141,247 @@ faucet:
 	require.NoError(t, err)
 	require.Equal(t, ":4700", FaucetHost(conf))
 }
+
+func TestParseValidators(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+  - name: bob
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    staked: "100000000stake"
+  - name: bob
+    staked: "50000000stake"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, []Validator{
+		{
+			Name:   "alice",
+			Staked: "100000000stake",
+		},
+		{
+			Name:   "bob",
+			Staked: "50000000stake",
+		},
+	}, conf.ListValidators())
+}
+
+func TestParseValidatorsInvalid(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+validators:
+  - name: alice
+    staked: "100000000stake"
+`
+
+	_, err := Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{"validator and validators cannot be used together"}, err)
+
+	confyml = `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    staked: "100000000stake"
+  - name: alice
+    staked: "100000000stake"
+`
+
+	_, err = Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{`validator "alice" is defined more than once`}, err)
+}
+
+func TestParseBuildWatch(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+build:
+  watch:
+    include: ["x/**/*.go", "app"]
+    exclude: ["**/*.pb.go"]
+    debounce: 500ms
+    manual: true
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, Watch{
+		Include:  []string{"x/**/*.go", "app"},
+		Exclude:  []string{"**/*.pb.go"},
+		Debounce: "500ms",
+		Manual:   true,
+	}, conf.Build.Watch)
+}
+
+func TestParseFaucetProtection(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+faucet:
+  name: alice
+  ip_limit:
+    requests: 5
+    subnet_requests: 20
+    window: 1h
+    trust_proxy: true
+  challenge:
+    proof_of_work: 20
+    captcha:
+      verify_url: https://hcaptcha.com/siteverify
+      secret: secret
+      site_key: key
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, FaucetIPLimit{
+		Requests:       5,
+		SubnetRequests: 20,
+		Window:         "1h",
+		TrustProxy:     true,
+	}, conf.Faucet.IPLimit)
+	require.Equal(t, FaucetChallenge{
+		ProofOfWork: 20,
+		Captcha: FaucetCaptcha{
+			VerifyURL: "https://hcaptcha.com/siteverify",
+			Secret:    "secret",
+			SiteKey:   "key",
+		},
+	}, conf.Faucet.Challenge)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "window: 1h", "window: soon", 1)))
+	require.Equal(t, &ValidationError{`invalid faucet.ip_limit.window duration "soon"`}, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "window: 1h", "", 1)))
+	require.Equal(t, &ValidationError{"faucet.ip_limit.window is required"}, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "secret: secret", "", 1)))
+	require.Equal(t, &ValidationError{"faucet.challenge.captcha.secret is required"}, err)
+}
+
+func TestParseFaucetPool(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+faucet:
+  name: alice
+  accounts: ["bob", "carol"]
+  low_balance: ["1000stake"]
+  balance_check_interval: 1m
+  refill:
+    from: treasury
+    coins: ["100000stake"]
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, []string{"bob", "carol"}, conf.Faucet.Accounts)
+	require.Equal(t, []string{"1000stake"}, conf.Faucet.LowBalance)
+	require.Equal(t, "1m", conf.Faucet.BalanceCheckInterval)
+	require.Equal(t, FaucetRefill{
+		From:  "treasury",
+		Coins: []string{"100000stake"},
+	}, conf.Faucet.Refill)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, `"carol"`, `"bob"`, 1)))
+	require.Equal(t, &ValidationError{`duplicated faucet account "bob"`}, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, `"carol"`, `"alice"`, 1)))
+	require.Equal(t, &ValidationError{`duplicated faucet account "alice"`}, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "interval: 1m", "interval: soon", 1)))
+	require.Equal(t, &ValidationError{`invalid faucet.balance_check_interval duration "soon"`}, err)
+
+	for _, account := range []string{"alice", "carol"} {
+		_, err = Parse(strings.NewReader(strings.Replace(confyml, "from: treasury", "from: "+account, 1)))
+		require.Equal(t, &ValidationError{fmt.Sprintf("faucet.refill.from %q cannot be a faucet account", account)}, err)
+	}
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, `low_balance: ["1000stake"]`, "", 1)))
+	require.Equal(t, &ValidationError{"faucet.low_balance is required to refill the faucet accounts"}, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, `coins: ["100000stake"]`, "", 1)))
+	require.Equal(t, &ValidationError{"faucet.refill.coins is required"}, err)
+}
+
+func TestParseVestingAndModuleAccounts(t *testing.T) {
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["100000000stake", "1000token"]
+    vesting:
+      coins: ["50000000stake"]
+      start_time: "2030-01-01T00:00:00Z"
+      end_time: "2031-01-01T00:00:00Z"
+  - name: bob
+    coins: ["1000token"]
+    vesting:
+      coins: ["500token"]
+      end_time: 720h
+  - name: community
+    coins: ["1000token"]
+    module: distribution
+validator:
+  name: alice
+  staked: "100000000stake"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, "distribution", conf.Accounts[2].Module)
+
+	alice := conf.Accounts[0].Vesting
+	require.Equal(t, VestingContinuous, alice.VestingType())
+	start, end, err := alice.Times(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(1893456000), start)
+	require.Equal(t, int64(1924992000), end)
+
+	bob := conf.Accounts[1].Vesting
+	require.Equal(t, VestingDelayed, bob.VestingType())
+	now := time.Unix(1000, 0)
+	start, end, err = bob.Times(now)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), start)
+	require.Equal(t, now.Add(720*time.Hour).Unix(), end)
+
+	cases := []struct {
+		old, new, err string
+	}{
+		{"end_time: 720h", "type: delayed", `account "bob" vesting: end_time is required`},
+		{`coins: ["500token"]`, `coins: ["5000token"]`, `account "bob" vesting: coins 5000token exceed the account coins 1000token`},
+		{"end_time: 720h", "end_time: 720h\n      type: linear", `account "bob" vesting: unknown type "linear", expected delayed or continuous`},
+		{"end_time: 720h", "end_time: 720h\n      type: continuous", `account "bob" vesting: start_time is required for continuous vesting`},
+		{"end_time: 720h", "end_time: later", `account "bob" vesting: invalid vesting time "later"`},
+		{`"2031-01-01T00:00:00Z"`, `"2029-01-01T00:00:00Z"`, `account "alice" vesting: start_time must be before end_time`},
+		{"module: distribution", "module: distribution\n    address: cosmos1abc", `module account "community" cannot have a mnemonic or an address`},
+		{"name: alice\n  staked", "name: community\n  staked", `validator "community" cannot be a module account`},
+		{"module: distribution", "module: gov", `module account "community" cannot fund module "gov", which checks its balance against its own state`},
+	}
+	for _, tt := range cases {
+		_, err := Parse(strings.NewReader(strings.Replace(confyml, tt.old, tt.new, 1)))
+		require.Equal(t, &ValidationError{tt.err}, err, tt.new)
+	}
+}
@@ -0,0 +1,261 @@
+package chainconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// LatestVersion 是配置格式的最新版本。
+// 沒有 version 字段的配置文件為版本 0。
+const LatestVersion = 1
+
+// versionKey 是保存配置格式版本的字段名稱。
+const versionKey = "version"
+
+// Migration 將配置從版本 From 升級到版本 From+1。
+type Migration struct {
+	// From 是遷移適用的配置版本。
+	From int
+
+	// Description 描述遷移對配置所做的修改。
+	Description string
+
+	// Apply 在配置的根映射上就地執行遷移，
+	// 它應該保留與遷移無關的節點及其注釋。
+	Apply func(root *ast.MappingNode) error
+}
+
+// Migrations 是按版本排序的遷移鏈，第 N 個遷移將配置從版本 N 升級到版本 N+1。
+var Migrations = []Migration{
+	{
+		From:        0,
+		Description: "replace faucet.port with faucet.host",
+		Apply:       migrateFaucetPort,
+	},
+}
+
+// UnsupportedVersionError 在配置的版本比支持的最新版本更新時返回。
+type UnsupportedVersionError struct {
+	Version int
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("config version %d is not supported, the latest supported version is %d", e.Version, LatestVersion)
+}
+
+// Version 返回 YAML 格式配置的版本。
+func Version(data []byte) (int, error) {
+	root, err := parseRoot(data)
+	if err != nil {
+		return 0, err
+	}
+	return rootVersion(root)
+}
+
+// Migrate 將 YAML 格式的配置升級到最新版本並返回升級後的配置和原始版本。
+// 遷移在 YAML 語法樹上執行，因此配置中的注釋會被保留。
+// 配置已經是最新版本時，返回原始數據。
+func Migrate(data []byte) (migrated []byte, from int, err error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	root, err := documentRoot(file)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	from, err = rootVersion(root)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from == LatestVersion {
+		return data, from, nil
+	}
+
+	for _, m := range Migrations[from:] {
+		if err := m.Apply(root); err != nil {
+			return nil, from, fmt.Errorf("cannot migrate config from version %d: %w", m.From, err)
+		}
+		if err := setMappingValue(root, versionKey, strconv.Itoa(m.From+1), true); err != nil {
+			return nil, from, err
+		}
+	}
+
+	return []byte(file.String() + "\n"), from, nil
+}
+
+// MigrateFile 將路徑中的配置文件就地升級到最新版本並返回原始版本。
+// 文件已經是最新版本時不會被修改。
+func MigrateFile(path string) (from int, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated, from, err := Migrate(data)
+	if err != nil || from == LatestVersion {
+		return from, err
+	}
+
+	return from, os.WriteFile(path, migrated, info.Mode())
+}
+
+// migrateFaucetPort 將已棄用的 faucet.port 替換為等效的 faucet.host。
+func migrateFaucetPort(root *ast.MappingNode) error {
+	faucet, ok := mappingValue(root, "faucet")
+	if !ok {
+		return nil
+	}
+
+	faucetMap, ok := childMapping(faucet)
+	if !ok {
+		return nil
+	}
+
+	port, ok := mappingValue(faucetMap, "port")
+	if !ok {
+		return nil
+	}
+
+	// 與 FaucetHost 一致，端口為 0 表示未設置，否則端口優先於主機。
+	value := scalarValue(port.Value)
+	if value != "" && value != "0" {
+		if err := setMappingValue(faucetMap, "host", strconv.Quote(":"+value), false); err != nil {
+			return err
+		}
+
+		host, _ := mappingValue(faucetMap, "host")
+		if host.Value.GetComment() == nil {
+			_ = host.Value.SetComment(port.Value.GetComment())
+		}
+	}
+
+	removeMappingValue(faucetMap, "port")
+	return nil
+}
+
+// parseRoot 解析 YAML 格式的配置並返回其根映射。
+func parseRoot(data []byte) (*ast.MappingNode, error) {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	return documentRoot(file)
+}
+
+// documentRoot 返回文件中第一個文檔的根映射。
+// 只有一個鍵的映射會被轉換為 MappingNode，以便添加新的鍵。
+func documentRoot(file *ast.File) (*ast.MappingNode, error) {
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return nil, errors.New("config is empty")
+	}
+
+	doc := file.Docs[0]
+	switch body := doc.Body.(type) {
+	case *ast.MappingNode:
+		return body, nil
+	case *ast.MappingValueNode:
+		root := ast.Mapping(body.GetToken(), false, body)
+		doc.Body = root
+		return root, nil
+	}
+
+	return nil, errors.New("config must be a mapping")
+}
+
+// childMapping 返回鍵值對的映射值。
+// 只有一個鍵的映射值會被轉換為 MappingNode，以便添加新的鍵。
+func childMapping(mv *ast.MappingValueNode) (*ast.MappingNode, bool) {
+	switch value := unwrapNode(mv.Value).(type) {
+	case *ast.MappingNode:
+		return value, true
+	case *ast.MappingValueNode:
+		m := ast.Mapping(value.GetToken(), false, value)
+		if err := mv.Replace(m); err != nil {
+			return nil, false
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// rootVersion 返回根映射中的配置版本。
+func rootVersion(root *ast.MappingNode) (int, error) {
+	node, ok := mappingValue(root, versionKey)
+	if !ok {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(scalarValue(node.Value))
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid config version %q", scalarValue(node.Value))
+	}
+	if version > LatestVersion {
+		return 0, &UnsupportedVersionError{version}
+	}
+	return version, nil
+}
+
+// mappingValue 返回映射中鍵為 key 的鍵值對。
+func mappingValue(m *ast.MappingNode, key string) (*ast.MappingValueNode, bool) {
+	for _, mv := range m.Values {
+		if scalarValue(mv.Key) == key {
+			return mv, true
+		}
+	}
+	return nil, false
+}
+
+// removeMappingValue 從映射中刪除鍵為 key 的鍵值對。
+func removeMappingValue(m *ast.MappingNode, key string) {
+	values := m.Values[:0]
+	for _, mv := range m.Values {
+		if scalarValue(mv.Key) != key {
+			values = append(values, mv)
+		}
+	}
+	m.Values = values
+}
+
+// setMappingValue 將映射中鍵 key 的值設置為 YAML 標量 value。
+// 鍵已存在時替換其值並保留注釋，否則添加新的鍵，prepend 為 true 時添加到映射的開頭。
+func setMappingValue(m *ast.MappingNode, key, value string, prepend bool) error {
+	snippet, err := parser.ParseBytes([]byte(fmt.Sprintf("%s: %s\n", key, value)), 0)
+	if err != nil {
+		return err
+	}
+
+	node, ok := snippet.Docs[0].Body.(*ast.MappingValueNode)
+	if !ok {
+		return fmt.Errorf("invalid value %q for %s", value, key)
+	}
+
+	if existing, ok := mappingValue(m, key); ok {
+		_ = node.Value.SetComment(existing.Value.GetComment())
+		return existing.Replace(node.Value)
+	}
+
+	// 使新的鍵與映射中的其他鍵對齊。
+	if len(m.Values) > 0 {
+		node.AddColumn(m.Values[0].Key.GetToken().Position.Column - 1)
+	}
+
+	if prepend {
+		m.Values = append([]*ast.MappingValueNode{node}, m.Values...)
+	} else {
+		m.Values = append(m.Values, node)
+	}
+	return nil
+}
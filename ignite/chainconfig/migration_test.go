@@ -0,0 +1,93 @@
+package chainconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	confyml := `# development config
+accounts:
+  - name: me
+    coins: ["1000token"]
+validator:
+  name: me
+  staked: "100000000stake"
+# faucet of the chain
+faucet:
+  name: me
+  port: 4600 # faucet port
+`
+
+	migrated, from, err := Migrate([]byte(confyml))
+	require.NoError(t, err)
+	require.Equal(t, 0, from)
+	require.Equal(t, `# development config
+version: 1
+accounts:
+  - name: me
+    coins: ["1000token"]
+validator:
+  name: me
+  staked: "100000000stake"
+# faucet of the chain
+faucet:
+  name: me
+  host: ":4600" # faucet port
+`, string(migrated))
+
+	version, err := Version(migrated)
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, version)
+
+	again, from, err := Migrate(migrated)
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, from)
+	require.Equal(t, migrated, again)
+}
+
+func TestMigrateUnsupportedVersion(t *testing.T) {
+	_, _, err := Migrate([]byte("version: 99\naccounts: []\n"))
+	var verr *UnsupportedVersionError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 99, verr.Version)
+
+	_, err = Parse(strings.NewReader("version: 99\naccounts: []\n"))
+	require.Error(t, err)
+}
+
+func TestParseMigratesInMemory(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  port: 4600
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, LatestVersion, conf.Version)
+	require.Equal(t, ":4600", conf.Faucet.Host)
+	require.Equal(t, 0, conf.Faucet.Port)
+}
+
+func TestMigrateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("accounts:\n  - name: me\n"), 0600))
+
+	from, err := MigrateFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, from)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "version: 1\naccounts:\n  - name: me\n", string(data))
+}
@@ -0,0 +1,90 @@
+package chainconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ProfileEnvVar 是在未明確指定時選擇配置檔案的環境變量。
+const ProfileEnvVar = "IGNITE_PROFILE"
+
+// envVarRe 匹配 ${NAME} 和帶默認值的 ${NAME:-default} 變量。
+var envVarRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ProfileFilePath 返回 path 的配置檔案文件路徑，
+// 例如 config.yml 和 dev 配置檔案返回 config.dev.yml。
+func ProfileFilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(path, ext), profile, ext)
+}
+
+// deepMerge 將 overlay 合併到 base 中並返回結果。
+// 兩邊都是映射的值會遞歸合併，其他值（包括列表）由 overlay 替換。
+func deepMerge(base, overlay map[string]interface{}) map[string]interface{} {
+	if base == nil {
+		base = make(map[string]interface{})
+	}
+
+	for key, overlayValue := range overlay {
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		overlayMap, overlayIsMap := overlayValue.(map[string]interface{})
+		if baseIsMap && overlayIsMap {
+			base[key] = deepMerge(baseMap, overlayMap)
+			continue
+		}
+		base[key] = overlayValue
+	}
+
+	return base
+}
+
+// interpolateEnv 將 v 中所有字符串值的 ${NAME} 變量替換為環境變量的值。
+// ${NAME:-default} 在環境變量未設置時使用默認值，沒有默認值的未設置變量返回錯誤。
+func interpolateEnv(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			interpolated, err := interpolateEnv(item)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = interpolated
+		}
+		return value, nil
+
+	case []interface{}:
+		for i, item := range value {
+			interpolated, err := interpolateEnv(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = interpolated
+		}
+		return value, nil
+
+	case string:
+		var err error
+		interpolated := envVarRe.ReplaceAllStringFunc(value, func(match string) string {
+			groups := envVarRe.FindStringSubmatch(match)
+			name, hasDefault, defaultValue := groups[1], groups[2] != "", groups[3]
+
+			if envValue, ok := os.LookupEnv(name); ok {
+				return envValue
+			}
+			if hasDefault {
+				return defaultValue
+			}
+			if err == nil {
+				err = &ValidationError{fmt.Sprintf("environment variable %q is not set", name)}
+			}
+			return match
+		})
+		return interpolated, err
+
+	default:
+		return v, nil
+	}
+}
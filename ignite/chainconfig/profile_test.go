@@ -0,0 +1,124 @@
+package chainconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const profileBaseConfig = `
+accounts:
+  - name: alice
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+host:
+  rpc: "0.0.0.0:26657"
+genesis:
+  chain_id: "mars"
+  app_state:
+    staking:
+      params:
+        bond_denom: "stake"
+`
+
+func TestParseFileWithProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(profileBaseConfig), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.ci.yml"), []byte(`
+accounts:
+  - name: bob
+    coins: ["5token"]
+validator:
+  name: bob
+host:
+  api: "0.0.0.0:1318"
+genesis:
+  app_state:
+    staking:
+      params:
+        max_validators: 5
+`), 0644))
+
+	conf, err := ParseFileWithProfile(path, "ci")
+	require.NoError(t, err)
+
+	// lists are replaced by the profile.
+	require.Equal(t, []Account{{Name: "bob", Coins: []string{"5token"}}}, conf.Accounts)
+
+	// maps are merged with the profile values having the precedence.
+	require.Equal(t, Validator{Name: "bob", Staked: "100000000stake"}, conf.Validator)
+	require.Equal(t, "0.0.0.0:26657", conf.Host.RPC)
+	require.Equal(t, "0.0.0.0:1318", conf.Host.API)
+	require.Equal(t, map[string]interface{}{
+		"bond_denom":     "stake",
+		"max_validators": uint64(5),
+	}, conf.Genesis["app_state"].(map[string]interface{})["staking"].(map[string]interface{})["params"])
+
+	_, err = ParseFileWithProfile(path, "missing")
+	require.Error(t, err)
+}
+
+func TestParseFileWithEmptyProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(profileBaseConfig), 0644))
+
+	base, err := ParseFileWithProfile(path, "")
+	require.NoError(t, err)
+
+	for name, profile := range map[string]string{
+		"empty":    "",
+		"comments": "# nothing to override yet\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "config.dev.yml"), []byte(profile), 0644))
+
+			conf, err := ParseFileWithProfile(path, "dev")
+			require.NoError(t, err)
+			require.Equal(t, base, conf)
+		})
+	}
+}
+
+func TestProfileFilePath(t *testing.T) {
+	require.Equal(t, "config.dev.yml", ProfileFilePath("config.yml", "dev"))
+	require.Equal(t, "/app/config.dev.yaml", ProfileFilePath("/app/config.yaml", "dev"))
+}
+
+func TestParseEnvInterpolation(t *testing.T) {
+	t.Setenv("IGNITE_TEST_MNEMONIC", "ozone unfold device")
+
+	confyml := `
+accounts:
+  - name: alice
+    coins: ["1000token"]
+    mnemonic: "${IGNITE_TEST_MNEMONIC}"
+validator:
+  name: alice
+  staked: "100stake"
+faucet:
+  host: "${IGNITE_TEST_FAUCET_HOST:-0.0.0.0:4600}"
+`
+	conf, err := Parse(strings.NewReader(confyml))
+	require.NoError(t, err)
+	require.Equal(t, "ozone unfold device", conf.Accounts[0].Mnemonic)
+	require.Equal(t, "0.0.0.0:4600", conf.Faucet.Host)
+
+	confyml = `
+accounts:
+  - name: alice
+    coins: ["1000token"]
+    mnemonic: "${IGNITE_TEST_UNSET_MNEMONIC}"
+validator:
+  name: alice
+  staked: "100stake"
+`
+	_, err = Parse(strings.NewReader(confyml))
+	require.Equal(t, &ValidationError{`environment variable "IGNITE_TEST_UNSET_MNEMONIC" is not set`}, err)
+}
@@ -0,0 +1,73 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// SchemaID 是配置 JSON Schema 的標識符。
+const SchemaID = "https://ignite.com/schemas/config.json"
+
+// JSONSchema 返回從 Config 結構生成的 config.yml 的 JSON Schema。
+// 結構不允許額外的屬性，因此編輯器可以像 Validate 一樣報告未知的字段。
+func JSONSchema() ([]byte, error) {
+	schema := typeSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = SchemaID
+	schema["title"] = "Ignite chain configuration"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// typeSchema 返回 Go 類型 t 的 JSON Schema。
+func typeSchema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := yamlFieldName(sf)
+			if name == "" {
+				continue
+			}
+			properties[name] = typeSchema(sf.Type)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": typeSchema(t.Elem()),
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": typeSchema(t.Elem()),
+		}
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	}
+
+	// interface{} 等類型可以包含任何值。
+	return map[string]interface{}{}
+}
@@ -0,0 +1,375 @@
+package chainconfig
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+
+	"github.com/ignite-hq/cli/ignite/pkg/localfs"
+)
+
+// fieldCheck 驗證一個標量字段的值。
+type fieldCheck func(value string) error
+
+// anyField 在 fieldChecks 中表示結構的所有字段。
+const anyField = "*"
+
+// fieldChecks 按結構類型和 YAML 字段名保存嚴格驗證時對字段值的檢查，
+// 列表字段的檢查應用於列表中的每個元素。
+var fieldChecks = map[reflect.Type]map[string]fieldCheck{
+	reflect.TypeOf(Account{}): {
+		"coins": checkCoin,
+	},
+	reflect.TypeOf(Vesting{}): {
+		"coins":      checkCoin,
+		"start_time": checkVestingTime,
+		"end_time":   checkVestingTime,
+	},
+	reflect.TypeOf(Validator{}): {
+		"staked": checkCoin,
+	},
+	reflect.TypeOf(Faucet{}): {
+		"coins":                  checkCoin,
+		"coins_max":              checkCoin,
+		"host":                   checkHostPort,
+		"low_balance":            checkCoin,
+		"balance_check_interval": checkDuration,
+	},
+	reflect.TypeOf(FaucetRefill{}): {
+		"coins": checkCoin,
+	},
+	reflect.TypeOf(Host{}): {
+		anyField: checkHostPort,
+	},
+	reflect.TypeOf(FaucetIPLimit{}): {
+		"window": checkDuration,
+	},
+	reflect.TypeOf(Watch{}): {
+		"include":  checkGlob,
+		"exclude":  checkGlob,
+		"debounce": checkDuration,
+	},
+}
+
+// ValidationIssue 是嚴格驗證在配置文件中發現的一個問題。
+type ValidationIssue struct {
+	// Line 和 Column 是問題在文件中的位置，從 1 開始。
+	Line   int
+	Column int
+
+	// Field 是出現問題的字段的路徑，例如 accounts[0].coins[1]。
+	Field string
+
+	// Message 描述問題。
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", i.Line, i.Column, i.Field, i.Message)
+}
+
+// StrictValidationError 在嚴格驗證發現問題時返回。
+type StrictValidationError struct {
+	// Path 是被驗證的配置文件的路徑。
+	Path string
+
+	// Issues 是發現的所有問題，按在文件中出現的順序排列。
+	Issues []ValidationIssue
+}
+
+func (e *StrictValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("config is not valid:")
+	for _, issue := range e.Issues {
+		fmt.Fprintf(&b, "\n  %s:%s", e.Path, issue)
+	}
+	return b.String()
+}
+
+// ValidateFile 嚴格驗證路徑中的配置文件。
+// 參見 Validate。
+func ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := Validate(data); err != nil {
+		if verr, ok := err.(*StrictValidationError); ok {
+			verr.Path = path
+		}
+		return err
+	}
+	return nil
+}
+
+// Validate 嚴格驗證 YAML 格式的配置。
+// 與 Parse 不同，它會報告未知的字段、格式錯誤的代幣、無效的 host:port 地址
+// 和重複的帳戶名稱，並以 *StrictValidationError 返回每個問題在文件中的位置。
+// 包含 ${ENV} 變量的值在替換之前無法驗證，因此會被跳過。
+func Validate(data []byte) error {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return err
+	}
+
+	v := &validator{accountNames: make(map[string]bool)}
+	for _, doc := range file.Docs {
+		if doc.Body != nil {
+			v.walk(doc.Body, reflect.TypeOf(Config{}), "")
+		}
+	}
+
+	if len(v.issues) > 0 {
+		return &StrictValidationError{Issues: v.issues}
+	}
+	return nil
+}
+
+// validator 遍歷 YAML 語法樹並將其與配置結構進行比較。
+type validator struct {
+	issues       []ValidationIssue
+	accountNames map[string]bool
+}
+
+func (v *validator) report(node ast.Node, field, format string, args ...interface{}) {
+	pos := nodePosition(node)
+	v.issues = append(v.issues, ValidationIssue{
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) walk(node ast.Node, t reflect.Type, field string) {
+	node = unwrapNode(node)
+	if node == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		values, ok := mappingValues(node)
+		if !ok {
+			if _, isNull := node.(*ast.NullNode); !isNull {
+				v.report(node, field, "expected a mapping")
+			}
+			return
+		}
+		v.walkStruct(values, t, field)
+
+	case reflect.Slice:
+		seq, ok := node.(*ast.SequenceNode)
+		if !ok {
+			if _, isNull := node.(*ast.NullNode); !isNull {
+				v.report(node, field, "expected a list")
+			}
+			return
+		}
+		for i, item := range seq.Values {
+			v.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", field, i))
+		}
+	}
+}
+
+func (v *validator) walkStruct(values []*ast.MappingValueNode, t reflect.Type, field string) {
+	checks := fieldChecks[t]
+
+	for _, mv := range values {
+		if _, ok := mv.Key.(*ast.MergeKeyNode); ok {
+			continue
+		}
+
+		name := scalarValue(mv.Key)
+		childField := joinField(field, name)
+
+		sf, ok := structFieldByYAMLName(t, name)
+		if !ok {
+			v.report(mv.Key, childField, "unknown field %q", name)
+			continue
+		}
+
+		if check, ok := checks[name]; ok {
+			v.checkValues(mv.Value, childField, check)
+		} else if check, ok := checks[anyField]; ok {
+			v.checkValues(mv.Value, childField, check)
+		}
+
+		if t == reflect.TypeOf(Account{}) && name == "name" {
+			accountName := scalarValue(mv.Value)
+			if v.accountNames[accountName] {
+				v.report(mv.Value, childField, "account %q is defined more than once", accountName)
+			}
+			v.accountNames[accountName] = true
+		}
+
+		v.walk(mv.Value, sf.Type, childField)
+	}
+}
+
+// checkValues 對標量值或列表中的每個標量值應用 check。
+func (v *validator) checkValues(node ast.Node, field string, check fieldCheck) {
+	node = unwrapNode(node)
+
+	if seq, ok := node.(*ast.SequenceNode); ok {
+		for i, item := range seq.Values {
+			v.checkValues(item, fmt.Sprintf("%s[%d]", field, i), check)
+		}
+		return
+	}
+
+	switch node.(type) {
+	case nil, *ast.NullNode, *ast.MappingNode, *ast.MappingValueNode:
+		return
+	}
+
+	value := scalarValue(node)
+	if envVarRe.MatchString(value) {
+		return
+	}
+	if err := check(value); err != nil {
+		v.report(node, field, "%s", err)
+	}
+}
+
+// checkCoin 驗證值是否為有效的代幣，例如 1000token。
+func checkCoin(value string) error {
+	if _, err := sdk.ParseCoinNormalized(value); err != nil {
+		return fmt.Errorf("invalid coin %q", value)
+	}
+	return nil
+}
+
+// checkHostPort 驗證值是否為有效的 host:port 地址，主機可以省略。
+// 空值表示不啟用可選的地址，例如 host.explorer 和 host.health。
+func checkHostPort(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return fmt.Errorf("invalid host:port address %q", value)
+	}
+
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("invalid port in address %q", value)
+	}
+	return nil
+}
+
+// checkGlob 驗證值是否為有效的 glob 模式。
+func checkGlob(value string) error {
+	if err := localfs.CheckGlob(value); err != nil {
+		return fmt.Errorf("invalid glob pattern %q", value)
+	}
+	return nil
+}
+
+// checkDuration 驗證值是否為有效的時間長度，例如 500ms。
+func checkDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("invalid duration %q", value)
+	}
+	return nil
+}
+
+// checkVestingTime 驗證值是否為有效的鎖倉時間，例如 2030-01-01T00:00:00Z 或 720h。
+func checkVestingTime(value string) error {
+	_, err := parseVestingTime(value, time.Now())
+	return err
+}
+
+// structFieldByYAMLName 返回 YAML 標籤名稱為 name 的結構字段。
+func structFieldByYAMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if yamlFieldName(sf) == name {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlFieldName 返回結構字段的 YAML 名稱，忽略的字段返回空字符串。
+func yamlFieldName(sf reflect.StructField) string {
+	if !sf.IsExported() {
+		return ""
+	}
+
+	name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(sf.Name)
+	}
+	return name
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// unwrapNode 返回錨點和標籤節點包裝的值節點。
+func unwrapNode(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		default:
+			return node
+		}
+	}
+}
+
+// mappingValues 返回映射節點的鍵值對。
+// 只有一個鍵的映射會被解析為單獨的 MappingValueNode。
+func mappingValues(node ast.Node) ([]*ast.MappingValueNode, bool) {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values, true
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}, true
+	}
+	return nil, false
+}
+
+func scalarValue(node ast.Node) string {
+	switch n := unwrapNode(node).(type) {
+	case *ast.StringNode:
+		return n.Value
+	case *ast.MappingKeyNode:
+		return scalarValue(n.Value)
+	case nil:
+		return ""
+	default:
+		return n.GetToken().Value
+	}
+}
+
+func nodePosition(node ast.Node) token.Position {
+	if node == nil || node.GetToken() == nil {
+		return token.Position{}
+	}
+	return *node.GetToken().Position
+}
@@ -0,0 +1,119 @@
+package chainconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+  - name: you
+    coins:
+      - 5000token
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  name: me
+  coins: ["5token"]
+  coins_max: ["${MAX_TOKEN:-100token}"]
+  host: :4500
+host:
+  rpc: "0.0.0.0:26657"
+  explorer: ""
+  health: ""
+genesis:
+  chain_id: mars
+`
+
+	require.NoError(t, Validate([]byte(confyml)))
+}
+
+func TestValidateIssues(t *testing.T) {
+	confyml := `accounts:
+  - name: me
+    coins: ["1000token", "1000"]
+  - name: me
+    coins: ["5000token"]
+validator:
+  name: me
+  staked: "100000000stake"
+faucet:
+  coins_maxx: ["100token"]
+host:
+  rpc: "0.0.0.0"
+  api: "0.0.0.0:99999"
+`
+
+	err := Validate([]byte(confyml))
+	require.Error(t, err)
+
+	verr, ok := err.(*StrictValidationError)
+	require.True(t, ok)
+	require.Equal(t, []ValidationIssue{
+		{Line: 3, Column: 26, Field: "accounts[0].coins[1]", Message: `invalid coin "1000"`},
+		{Line: 4, Column: 11, Field: "accounts[1].name", Message: `account "me" is defined more than once`},
+		{Line: 10, Column: 3, Field: "faucet.coins_maxx", Message: `unknown field "coins_maxx"`},
+		{Line: 12, Column: 8, Field: "host.rpc", Message: `invalid host:port address "0.0.0.0"`},
+		{Line: 13, Column: 8, Field: "host.api", Message: `invalid port in address "0.0.0.0:99999"`},
+	}, verr.Issues)
+}
+
+func TestValidateBuildWatch(t *testing.T) {
+	confyml := `build:
+  watch:
+    include: ["x/**/*.go", "x/["]
+    debounce: soon
+`
+
+	err := Validate([]byte(confyml))
+
+	var verr *StrictValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, []ValidationIssue{
+		{Line: 3, Column: 28, Field: "build.watch.include[1]", Message: `invalid glob pattern "x/["`},
+		{Line: 4, Column: 15, Field: "build.watch.debounce", Message: `invalid duration "soon"`},
+	}, verr.Issues)
+}
+
+func TestValidateVesting(t *testing.T) {
+	confyml := `accounts:
+  - name: alice
+    vesting:
+      coins: ["100"]
+      end_time: later
+`
+
+	err := Validate([]byte(confyml))
+
+	var verr *StrictValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, []ValidationIssue{
+		{Line: 4, Column: 15, Field: "accounts[0].vesting.coins[0]", Message: `invalid coin "100"`},
+		{Line: 5, Column: 17, Field: "accounts[0].vesting.end_time", Message: `invalid vesting time "later"`},
+	}, verr.Issues)
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		AdditionalProperties bool `json:"additionalProperties"`
+		Properties           map[string]struct {
+			Type       string                     `json:"type"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.False(t, schema.AdditionalProperties)
+	require.Equal(t, "array", schema.Properties["accounts"].Type)
+	require.Equal(t, "object", schema.Properties["faucet"].Type)
+	require.Contains(t, schema.Properties["faucet"].Properties, "coins_max")
+	require.Contains(t, schema.Properties["init"].Properties, "keyring-backend")
+}
@@ -19,6 +19,9 @@ func NewChain() *cobra.Command {
 		NewChainInit(),
 		NewChainFaucet(),
 		NewChainSimulate(),
+		NewChainSnapshot(),
+		NewChainConfig(),
+		NewChainUpgradeTest(),
 	)
 
 	return c
@@ -16,6 +16,7 @@ const (
 	flagRelease        = "release"
 	flagReleaseTargets = "release.targets"
 	flagReleasePrefix  = "release.prefix"
+	flagCosmovisor     = "cosmovisor"
 )
 
 // NewChainBuild 返回一個新的構建命令來構建區塊鏈應用程序。
@@ -29,8 +30,19 @@ func NewChainBuild() *cobra.Command {
 使用 GOOS:GOARCH 構建標籤指定發布目標。
 如果未指定可選的 --release.targets，則會為您當前的環境創建一個二進製文件。
 
+發布構建是可重現的：二進製文件使用 -trimpath 和固定的構建 ID 構建，
+tarball 中文件的時間戳來自 SOURCE_DATE_EPOCH 環境變量（默認為 HEAD 提交的時間）。
+release/ 目錄還包含每個目標的 JSON 清單（Go 版本、Cosmos SDK 版本、git 提交和二進製文件校驗和）、
+從 go.mod 生成的 CycloneDX SBOM 和所有文件的 release_checksum。
+
+要在 cosmovisor 下運行鏈，請使用 --cosmovisor 標誌和升級名稱。
+二進製文件按 cosmovisor 的目錄結構構建在鏈的主目錄中：
+升級名稱為 genesis 時放在 cosmovisor/genesis/bin 中，
+否則放在 cosmovisor/upgrades/<升級名稱>/bin 中，旁邊還有一個 upgrade-info.json 模板。
+
 示例用法：
 	- ignite chain build
+	- ignite chain build --cosmovisor v2
 	- ignite chain build --release -t linux:amd64 -t darwin:amd64 -t darwin:arm64`,
 		Args: cobra.NoArgs,
 		RunE: chainBuildHandler,
@@ -39,11 +51,13 @@ func NewChainBuild() *cobra.Command {
 	flagSetPath(c)
 	flagSetClearCache(c)
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetProfile())
 	c.Flags().AddFlagSet(flagSetProto3rdParty("Available only without the --release flag"))
 	c.Flags().Bool(flagRelease, false, "build for a release")
 	c.Flags().StringSliceP(flagReleaseTargets, "t", []string{}, "release targets. Available only with --release flag")
 	c.Flags().String(flagReleasePrefix, "", "tarball prefix for each release target. Available only with --release flag")
 	c.Flags().StringP(flagOutput, "o", "", "binary output path")
+	c.Flags().String(flagCosmovisor, "", "upgrade name to build the binary for in the cosmovisor directory of the chain home (genesis for the genesis binary)")
 	c.Flags().BoolP("verbose", "v", false, "Verbose output")
 
 	return c
@@ -55,6 +69,7 @@ func chainBuildHandler(cmd *cobra.Command, _ []string) error {
 		releaseTargets, _ = cmd.Flags().GetStringSlice(flagReleaseTargets)
 		releasePrefix, _  = cmd.Flags().GetString(flagReleasePrefix)
 		output, _         = cmd.Flags().GetString(flagOutput)
+		upgradeName, _    = cmd.Flags().GetString(flagCosmovisor)
 	)
 
 	chainOption := []chain.Option{
@@ -87,6 +102,17 @@ func chainBuildHandler(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if upgradeName != "" {
+		binaryPath, err := c.BuildCosmovisor(cmd.Context(), cacheStorage, upgradeName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🗃  Cosmovisor binary built at the path: %s\n", colors.Info(binaryPath))
+
+		return nil
+	}
+
 	binaryName, err := c.Build(cmd.Context(), cacheStorage, output)
 	if err != nil {
 		return err
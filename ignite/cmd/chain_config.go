@@ -0,0 +1,58 @@
+package ignitecmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+)
+
+// NewChainConfig 返回一個命令，該命令將處理鏈配置文件的子命令分組。
+func NewChainConfig() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config [command]",
+		Short: "驗證和遷移鏈的配置文件並生成其 JSON Schema",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewChainConfigValidate(),
+		NewChainConfigMigrate(),
+		NewChainConfigSchema(),
+	)
+
+	return c
+}
+
+func flagSetChainConfig(c *cobra.Command) {
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetProfile())
+	c.Flags().StringP(flagConfig, "c", "", "熊網鏈配置文件 (default: ./config.yml)")
+}
+
+// chainConfigFile 返回命令要處理的配置文件路徑和配置檔案名稱。
+func chainConfigFile(cmd *cobra.Command) (path, profile string, err error) {
+	path, err = cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return "", "", err
+	}
+
+	if path == "" {
+		appPath, err := filepath.Abs(flagGetPath(cmd))
+		if err != nil {
+			return "", "", err
+		}
+		if path, err = chainconfig.LocateDefault(appPath); err != nil {
+			return "", "", err
+		}
+	}
+
+	profile = getProfile(cmd)
+	if profile == "" {
+		profile = os.Getenv(chainconfig.ProfileEnvVar)
+	}
+
+	return path, profile, nil
+}
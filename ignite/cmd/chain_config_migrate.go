@@ -0,0 +1,56 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+)
+
+// NewChainConfigMigrate 創建一個新命令以將鏈的配置文件遷移到最新版本。
+func NewChainConfigMigrate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "將鏈的配置文件遷移到最新版本",
+		Long: fmt.Sprintf(`將鏈的配置文件就地遷移到最新版本 (%d)。
+
+遷移會保留配置文件中的注釋。使用配置檔案時，配置檔案也會被遷移。
+舊版本的配置文件仍然可以使用，它們在每次讀取時都會在內存中遷移。`, chainconfig.LatestVersion),
+		Args: cobra.NoArgs,
+		RunE: chainConfigMigrateHandler,
+	}
+
+	flagSetChainConfig(c)
+
+	return c
+}
+
+func chainConfigMigrateHandler(cmd *cobra.Command, args []string) error {
+	configPath, profile, err := chainConfigFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	paths := []string{configPath}
+	if profile != "" {
+		paths = append(paths, chainconfig.ProfileFilePath(configPath, profile))
+	}
+
+	for _, path := range paths {
+		from, err := chainconfig.MigrateFile(path)
+		if err != nil {
+			return err
+		}
+
+		if from == chainconfig.LatestVersion {
+			fmt.Printf("✅ %s 已經是最新版本 %d\n", colors.Info(path), from)
+			continue
+		}
+
+		fmt.Printf("🔄 %s 已從版本 %d 遷移到版本 %d\n", colors.Info(path), from, chainconfig.LatestVersion)
+	}
+
+	return nil
+}
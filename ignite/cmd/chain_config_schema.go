@@ -0,0 +1,34 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+)
+
+// NewChainConfigSchema 創建一個新命令以打印鏈配置文件的 JSON Schema。
+func NewChainConfigSchema() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "schema",
+		Short: "打印鏈配置文件的 JSON Schema",
+		Long: `打印鏈配置文件的 JSON Schema。
+
+Schema 可以在編輯器中使用，以在編輯 config.yml 時提供自動補全和驗證。`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigSchemaHandler,
+	}
+
+	return c
+}
+
+func chainConfigSchemaHandler(cmd *cobra.Command, args []string) error {
+	schema, err := chainconfig.JSONSchema()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(schema))
+	return nil
+}
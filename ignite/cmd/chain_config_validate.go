@@ -0,0 +1,56 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+)
+
+// NewChainConfigValidate 創建一個新命令以嚴格驗證鏈的配置文件。
+func NewChainConfigValidate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "validate",
+		Short: "嚴格驗證鏈的配置文件",
+		Long: `嚴格驗證鏈的配置文件。
+
+報告未知的字段、格式錯誤的代幣、無效的 host:port 地址和重複的帳戶名稱，
+以及每個問題在文件中的行和列。使用配置檔案時，配置檔案也會被驗證。`,
+		Args: cobra.NoArgs,
+		RunE: chainConfigValidateHandler,
+	}
+
+	flagSetChainConfig(c)
+
+	return c
+}
+
+func chainConfigValidateHandler(cmd *cobra.Command, args []string) error {
+	configPath, profile, err := chainConfigFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	paths := []string{configPath}
+	if profile != "" {
+		paths = append(paths, chainconfig.ProfileFilePath(configPath, profile))
+	}
+
+	for _, path := range paths {
+		if err := chainconfig.ValidateFile(path); err != nil {
+			return err
+		}
+	}
+
+	// 合併後的配置還必須通過解析時的驗證。
+	if _, err := chainconfig.ParseFileWithProfile(configPath, profile); err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Printf("✅ %s 有效\n", colors.Info(path))
+	}
+	return nil
+}
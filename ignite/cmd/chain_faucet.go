@@ -21,6 +21,7 @@ func NewChainFaucet() *cobra.Command {
 
 	flagSetPath(c)
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetProfile())
 	c.Flags().BoolP("verbose", "v", false, "Verbose output")
 
 	return c
@@ -54,7 +55,7 @@ func chainFaucetHandler(cmd *cobra.Command, args []string) error {
 	}
 
 	// 從水龍頭執行轉移
-	if err := faucet.Transfer(cmd.Context(), toAddress, parsedCoins); err != nil {
+	if _, err := faucet.Transfer(cmd.Context(), toAddress, parsedCoins); err != nil {
 		return err
 	}
 
@@ -1,8 +1,13 @@
 package ignitecmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/ignite-hq/cli/ignite/pkg/nodelog"
 	"github.com/ignite-hq/cli/ignite/services/chain"
 )
 
@@ -10,6 +15,12 @@ const (
 	flagForceReset = "force-reset"
 	flagResetOnce  = "reset-once"
 	flagConfig     = "config"
+	flagSnapshot   = "snapshot"
+	flagLogFilter  = "log-filter"
+	flagTarget     = "target"
+
+	outputText = "text"
+	outputJSON = "json"
 )
 
 // NewChainServe 創建一個新的服務命令來服務於區塊鏈。
@@ -25,18 +36,51 @@ func NewChainServe() *cobra.Command {
 	flagSetPath(c)
 	flagSetClearCache(c)
 	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetProfile())
 	c.Flags().AddFlagSet(flagSetProto3rdParty(""))
 	c.Flags().BoolP("verbose", "v", false, "詳細輸出")
 	c.Flags().BoolP(flagForceReset, "f", false, "在啟動和每次項目源更改時,強制重置應用程序狀態")
 	c.Flags().BoolP(flagResetOnce, "r", false, "首次啟動時重置應用程序狀態")
 	c.Flags().StringP(flagConfig, "c", "", "熊網鏈配置文件 (default: ./config.yml)")
+	c.Flags().String(flagSnapshot, "", "首次啟動時從命名快照恢復應用程序狀態")
+	c.Flags().Bool(flagCosmovisor, false, "在 cosmovisor 下運行節點，使用 \"ignite chain build --cosmovisor\" 構建升級二進製文件")
+	c.Flags().String(flagTarget, chain.TargetLocal, fmt.Sprintf("構建和運行節點的目標 (%s)，docker 在從項目 Dockerfile 構建的鏡像中構建二進製文件並在容器中運行節點", strings.Join(chain.Targets(), "|")))
+	c.Flags().String(flagLogFilter, "", "按模塊過濾節點日誌並輸出到終端，例如 x/bank=debug,consensus=error,*=info")
+	c.Flags().StringP(flagOutput, "o", outputText, "輸出格式 (text|json)，json 將生命週期事件以換行分隔的 JSON 輸出到標準輸出")
 
 	return c
 }
 
 func chainServeHandler(cmd *cobra.Command, args []string) error {
-	chainOption := []chain.Option{
-		chain.LogLevel(logLevel(cmd)),
+	output, err := cmd.Flags().GetString(flagOutput)
+	if err != nil {
+		return err
+	}
+
+	var chainOption []chain.Option
+	switch output {
+	case outputText:
+		chainOption = append(chainOption, chain.LogLevel(logLevel(cmd)))
+	case outputJSON:
+		// 標準輸出僅保留給 JSON 事件，因此不輸出人類可讀的日誌
+		chainOption = append(chainOption,
+			chain.LogLevel(chain.LogSilent),
+			chain.EventOutput(os.Stdout),
+		)
+	default:
+		return fmt.Errorf("無效的輸出格式 %q，支持的格式: %s, %s", output, outputText, outputJSON)
+	}
+
+	logFilter, err := cmd.Flags().GetString(flagLogFilter)
+	if err != nil {
+		return err
+	}
+	if logFilter != "" {
+		filter, err := nodelog.ParseFilter(logFilter)
+		if err != nil {
+			return err
+		}
+		chainOption = append(chainOption, chain.LogFilter(filter))
 	}
 
 	if flagGetProto3rdParty(cmd) {
@@ -80,5 +124,27 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 		serveOptions = append(serveOptions, chain.ServeResetOnce())
 	}
 
+	snapshot, err := cmd.Flags().GetString(flagSnapshot)
+	if err != nil {
+		return err
+	}
+	if snapshot != "" {
+		serveOptions = append(serveOptions, chain.ServeSnapshot(snapshot))
+	}
+
+	cosmovisor, err := cmd.Flags().GetBool(flagCosmovisor)
+	if err != nil {
+		return err
+	}
+	if cosmovisor {
+		serveOptions = append(serveOptions, chain.ServeCosmovisor())
+	}
+
+	target, err := cmd.Flags().GetString(flagTarget)
+	if err != nil {
+		return err
+	}
+	serveOptions = append(serveOptions, chain.ServeTarget(target))
+
 	return c.Serve(cmd.Context(), cacheStorage, serveOptions...)
 }
@@ -0,0 +1,56 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
+	"github.com/ignite-hq/cli/ignite/services/chain"
+)
+
+// NewChainSnapshot 返回一個命令，該命令將管理鏈狀態快照的子命令分組。
+func NewChainSnapshot() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "snapshot [command]",
+		Short: "保存、恢復和管理區塊鏈狀態的命名快照",
+		Long: `保存、恢復和管理區塊鏈狀態的命名快照。
+
+快照是鏈導出的創世狀態，保存在用戶緩存目錄的 ignite/snapshots/[chain-id] 中
+（例如 Linux 上的 ~/.cache/ignite/snapshots/[chain-id]）。
+保存和恢復快照時，鏈不能在運行中。
+使用 "ignite chain serve --snapshot [name]" 從快照啟動鏈。`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(
+		NewChainSnapshotSave(),
+		NewChainSnapshotRestore(),
+		NewChainSnapshotList(),
+		NewChainSnapshotDelete(),
+	)
+
+	return c
+}
+
+func flagSetChainSnapshot(c *cobra.Command) {
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().AddFlagSet(flagSetProfile())
+	c.Flags().StringP(flagConfig, "c", "", "熊網鏈配置文件 (default: ./config.yml)")
+}
+
+func newChainSnapshotChain(cmd *cobra.Command) (*chain.Chain, error) {
+	chainOption := []chain.Option{
+		chain.LogLevel(logLevel(cmd)),
+		chain.KeyringBackend(chaincmd.KeyringBackendTest),
+	}
+
+	config, err := cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return nil, err
+	}
+	if config != "" {
+		chainOption = append(chainOption, chain.ConfigFile(config))
+	}
+
+	return newChainWithHomeFlags(cmd, chainOption...)
+}
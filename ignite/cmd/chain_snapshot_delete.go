@@ -0,0 +1,37 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+)
+
+// NewChainSnapshotDelete 創建一個新命令以刪除鏈的快照。
+func NewChainSnapshotDelete() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "刪除命名快照",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotDeleteHandler,
+	}
+
+	flagSetChainSnapshot(c)
+
+	return c
+}
+
+func chainSnapshotDeleteHandler(cmd *cobra.Command, args []string) error {
+	c, err := newChainSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteSnapshot(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("🗑  快照 %s 已刪除\n", colors.Info(args[0]))
+	return nil
+}
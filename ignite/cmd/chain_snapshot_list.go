@@ -0,0 +1,51 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/entrywriter"
+)
+
+var snapshotSummaryHeader = []string{"name", "height", "source checksum", "created at"}
+
+// NewChainSnapshotList 創建一個新命令以列出鏈的快照。
+func NewChainSnapshotList() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "列出鏈的所有快照",
+		Args:  cobra.NoArgs,
+		RunE:  chainSnapshotListHandler,
+	}
+
+	flagSetChainSnapshot(c)
+
+	return c
+}
+
+func chainSnapshotListHandler(cmd *cobra.Command, args []string) error {
+	c, err := newChainSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := c.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	var entries [][]string
+	for _, s := range snapshots {
+		entries = append(entries, []string{
+			s.Name,
+			fmt.Sprintf("%d", s.Height),
+			s.SourceChecksum,
+			s.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return entrywriter.MustWrite(os.Stdout, snapshotSummaryHeader, entries...)
+}
@@ -0,0 +1,37 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+)
+
+// NewChainSnapshotRestore 創建一個新命令以從快照恢復鏈狀態。
+func NewChainSnapshotRestore() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "restore [name]",
+		Short: "重置鏈狀態並從命名快照恢復",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotRestoreHandler,
+	}
+
+	flagSetChainSnapshot(c)
+
+	return c
+}
+
+func chainSnapshotRestoreHandler(cmd *cobra.Command, args []string) error {
+	c, err := newChainSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RestoreSnapshot(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("💿 已從快照 %s 恢復鏈狀態\n", colors.Info(args[0]))
+	return nil
+}
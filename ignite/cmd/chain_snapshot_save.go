@@ -0,0 +1,38 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+)
+
+// NewChainSnapshotSave 創建一個新命令以保存鏈狀態的快照。
+func NewChainSnapshotSave() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "save [name]",
+		Short: "將鏈的當前狀態保存為命名快照",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotSaveHandler,
+	}
+
+	flagSetChainSnapshot(c)
+
+	return c
+}
+
+func chainSnapshotSaveHandler(cmd *cobra.Command, args []string) error {
+	c, err := newChainSnapshotChain(cmd)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := c.SaveSnapshot(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("💿 快照 %s 已保存，高度 %d\n", colors.Info(snapshot.Name), snapshot.Height)
+	return nil
+}
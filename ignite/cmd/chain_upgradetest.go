@@ -0,0 +1,93 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
+	"github.com/ignite-hq/cli/ignite/pkg/cliui/colors"
+	"github.com/ignite-hq/cli/ignite/services/chain"
+)
+
+const (
+	flagUpgradeFrom = "from"
+	flagUpgradeTo   = "to"
+	flagUpgradeName = "name"
+)
+
+// NewChainUpgradeTest 返回一個新命令，在本地演練兩個 git 引用之間的軟件升級。
+func NewChainUpgradeTest() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "upgrade-test",
+		Short: "Rehearse a software upgrade between two git refs locally",
+		Long: `在本地演練 x/upgrade 軟件升級。
+
+命令將應用程序在 --from 和 --to 引用的源代碼檢出到臨時目錄並分別構建，
+用 --from 的二進製文件在臨時主目錄中初始化並啟動鏈（包括配置中的所有驗證者），
+然後由第一個驗證者提交名為 --name 的軟件升級治理提案，所有驗證者投贊成票。
+鏈在升級高度停止後，命令換上 --to 的二進製文件並重啟鏈，
+確認升級處理程序和存儲遷移成功執行並且鏈在升級高度之後繼續出塊。
+
+--to 引用中的應用程序必須為 --name 註冊升級處理程序。
+演練失敗時臨時主目錄會被保留，以便檢查節點的數據。
+
+示例用法：
+	- ignite chain upgrade-test --from v1.0.0 --to main --name v2`,
+		Args: cobra.NoArgs,
+		RunE: chainUpgradeTestHandler,
+	}
+
+	flagSetPath(c)
+	flagSetClearCache(c)
+	c.Flags().AddFlagSet(flagSetProfile())
+	c.Flags().String(flagUpgradeFrom, "", "git ref of the binary running before the upgrade")
+	c.Flags().String(flagUpgradeTo, "", "git ref of the binary running after the upgrade")
+	c.Flags().String(flagUpgradeName, "", "name of the upgrade plan handled by the new binary")
+	c.Flags().BoolP("verbose", "v", false, "Verbose output")
+
+	c.MarkFlagRequired(flagUpgradeFrom)
+	c.MarkFlagRequired(flagUpgradeTo)
+	c.MarkFlagRequired(flagUpgradeName)
+
+	return c
+}
+
+func chainUpgradeTestHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		from, _ = cmd.Flags().GetString(flagUpgradeFrom)
+		to, _   = cmd.Flags().GetString(flagUpgradeTo)
+		name, _ = cmd.Flags().GetString(flagUpgradeName)
+	)
+
+	c, err := newChainWithHomeFlags(
+		cmd,
+		chain.LogLevel(logLevel(cmd)),
+		chain.KeyringBackend(chaincmd.KeyringBackendTest),
+	)
+	if err != nil {
+		return err
+	}
+
+	cacheStorage, err := newCache(cmd)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.UpgradeTest(cmd.Context(), cacheStorage, from, to, name)
+	if err != nil {
+		if result.Home != "" {
+			fmt.Printf("❌ Upgrade test failed, the chain home is kept at: %s\n", colors.Info(result.Home))
+		}
+		return err
+	}
+
+	fmt.Printf("✅ Upgrade %s applied at height %s (proposal %d)\n",
+		colors.Info(result.Name),
+		colors.Info(result.AppliedHeight),
+		result.ProposalID,
+	)
+	fmt.Printf("   from %s to %s\n", colors.Info(result.FromCommit), colors.Info(result.ToCommit))
+
+	return nil
+}
@@ -32,6 +32,7 @@ const (
 	flagProto3rdParty = "proto-all-modules"
 	flagYes           = "yes"
 	flagClearCache    = "clear-cache"
+	flagProfile       = "profile"
 
 	checkVersionTimeout = time.Millisecond * 600
 	cacheFileName       = "ignite_cache.db"
@@ -69,6 +70,7 @@ ignite scaffold chain bnk`,
 	c.AddCommand(NewNetwork())
 	c.AddCommand(NewAccount())
 	c.AddCommand(NewRelayer())
+	c.AddCommand(NewWorkspace())
 	c.AddCommand(NewTools())
 	c.AddCommand(NewDocs())
 	c.AddCommand(NewVersion())
@@ -139,6 +141,17 @@ func flagGetProto3rdParty(cmd *cobra.Command) bool {
 	return isEnabled
 }
 
+func flagSetProfile() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagProfile, "", fmt.Sprintf("合併到配置文件之上的配置檔案，例如 dev 對應 config.dev.yml (default: $%s)", chainconfig.ProfileEnvVar))
+	return fs
+}
+
+func getProfile(cmd *cobra.Command) (profile string) {
+	profile, _ = cmd.Flags().GetString(flagProfile)
+	return
+}
+
 func flagSetClearCache(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool(flagClearCache, false, "清除構建緩存（高級）")
 }
@@ -154,6 +167,11 @@ func newChainWithHomeFlags(cmd *cobra.Command, chainOption ...chain.Option) (*ch
 		chainOption = append(chainOption, chain.HomePath(home))
 	}
 
+	// 檢查是否提供了配置檔案
+	if profile := getProfile(cmd); profile != "" {
+		chainOption = append(chainOption, chain.Profile(profile))
+	}
+
 	appPath := flagGetPath(cmd)
 	absPath, err := filepath.Abs(appPath)
 	if err != nil {
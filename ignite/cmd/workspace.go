@@ -0,0 +1,21 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspace 返回一個新的工作區命令。
+func NewWorkspace() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "workspace",
+		Aliases: []string{"w"},
+		Short:   "一起服務多條鏈並通過 IBC 連接它們",
+		Long: `工作區文件（默認為 workspace.yml）列出多個鏈項目，以及它們之間要連接的 IBC 路徑。
+
+"ignite workspace serve" 使用不衝突的端口服務所有鏈，在所有鏈啟動後配置中繼器路徑並開始中繼。`,
+	}
+
+	c.AddCommand(NewWorkspaceServe())
+
+	return c
+}
@@ -0,0 +1,94 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite-hq/cli/ignite/services/chain"
+	"github.com/ignite-hq/cli/ignite/services/workspace"
+)
+
+const flagWorkspace = "workspace"
+
+// NewWorkspaceServe 創建一個新的命令來服務工作區中的所有鏈並在它們之間中繼數據包。
+func NewWorkspaceServe() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "服務工作區中的所有鏈並通過 IBC 連接它們",
+		Long: `服務工作區文件中列出的所有鏈，第 n 條鏈的端口增加 n*port_offset 以避免衝突。
+所有鏈出塊後，使用 Ignite 帳戶創建鏈之間的 IBC 路徑並開始中繼數據包。
+任何一條鏈的狀態被重置後，與其相連的路徑會自動重新創建。
+
+使用 --reset-once 重置所有鏈的狀態，從頭重建整個拓撲。`,
+		Args: cobra.NoArgs,
+		RunE: workspaceServeHandler,
+	}
+
+	flagSetClearCache(c)
+	c.Flags().AddFlagSet(flagSetProfile())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().StringP(flagWorkspace, "w", workspace.DefaultFileName, "工作區文件")
+	c.Flags().BoolP("verbose", "v", false, "詳細輸出")
+	c.Flags().BoolP(flagForceReset, "f", false, "在啟動和每次項目源更改時,強制重置所有鏈的狀態")
+	c.Flags().BoolP(flagResetOnce, "r", false, "首次啟動時重置所有鏈的狀態並重新創建中繼器路徑")
+
+	return c
+}
+
+func workspaceServeHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ca.EnsureDefaultAccount(); err != nil {
+		return err
+	}
+
+	var chainOptions []chain.Option
+	if profile := getProfile(cmd); profile != "" {
+		chainOptions = append(chainOptions, chain.Profile(profile))
+	}
+
+	path, err := cmd.Flags().GetString(flagWorkspace)
+	if err != nil {
+		return err
+	}
+
+	w, err := workspace.New(
+		path,
+		ca,
+		workspace.LogLevel(logLevel(cmd)),
+		workspace.ChainOptions(chainOptions...),
+	)
+	if err != nil {
+		return err
+	}
+
+	var serveOptions []workspace.ServeOption
+	forceReset, err := cmd.Flags().GetBool(flagForceReset)
+	if err != nil {
+		return err
+	}
+	if forceReset {
+		serveOptions = append(serveOptions, workspace.ServeForceReset())
+	}
+	resetOnce, err := cmd.Flags().GetBool(flagResetOnce)
+	if err != nil {
+		return err
+	}
+	if resetOnce {
+		serveOptions = append(serveOptions, workspace.ServeResetOnce())
+	}
+	if flagGetClearCache(cmd) {
+		serveOptions = append(serveOptions, workspace.ServeClearCache())
+	}
+
+	return w.Serve(cmd.Context(), serveOptions...)
+}
@@ -7,17 +7,21 @@ import (
 	"time"
 )
 
-// Find finds n number of unused ports.
+// Find finds n number of distinct unused ports.
 // it is not guaranteed that these ports will not be allocated to
 // another program in the time of calling Find().
 func Find(n int) (ports []int, err error) {
 	min := 44000
 	max := 55000
 
+	found := make(map[int]bool)
 	for i := 0; i < n; i++ {
 		for {
 			rand.Seed(time.Now().UnixNano())
 			port := rand.Intn(max-min+1) + min
+			if found[port] {
+				continue
+			}
 
 			conn, err := net.Dial("tcp", fmt.Sprintf(":%d", port))
 			// if there is an error, this might mean that no one is listening from this port
@@ -26,6 +30,7 @@ func Find(n int) (ports []int, err error) {
 				conn.Close()
 				continue
 			}
+			found[port] = true
 			ports = append(ports, port)
 			break
 		}
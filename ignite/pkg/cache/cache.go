@@ -126,6 +126,36 @@ func (c Cache[T]) Get(key string) (val T, err error) {
 	return val, err
 }
 
+// GetAll fetches all the values within the namespace, mapped by their key.
+func (c Cache[T]) GetAll() (map[string]T, error) {
+	db, err := openDb(c.storage.storagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	values := make(map[string]T)
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(c.namespace))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			var decodedVal T
+			d := gob.NewDecoder(bytes.NewReader(v))
+			if err := d.Decode(&decodedVal); err != nil {
+				return err
+			}
+
+			values[string(k)] = decodedVal
+			return nil
+		})
+	})
+
+	return values, err
+}
+
 // Delete removes a value for key within the namespace
 func (c Cache[T]) Delete(key string) error {
 	db, err := openDb(c.storage.storagePath)
@@ -144,6 +144,29 @@ func TestDeleteKey(t *testing.T) {
 	require.Equal(t, cache.ErrorNotFound, err)
 }
 
+func TestGetAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheStorage, err := cache.NewStorage(filepath.Join(tmpDir, "testdbfile.db"))
+	require.NoError(t, err)
+
+	strNamespace := cache.New[string](cacheStorage, "myNameSpace")
+
+	values, err := strNamespace.GetAll()
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	err = strNamespace.Put("myKey", "myValue")
+	require.NoError(t, err)
+	err = strNamespace.Put("myOtherKey", "myOtherValue")
+	require.NoError(t, err)
+	err = cache.New[string](cacheStorage, "myOtherNameSpace").Put("myKey", "someValue")
+	require.NoError(t, err)
+
+	values, err = strNamespace.GetAll()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"myKey": "myValue", "myOtherKey": "myOtherValue"}, values)
+}
+
 func TestClearStorage(t *testing.T) {
 	tmpDir := t.TempDir()
 	cacheStorage, err := cache.NewStorage(filepath.Join(tmpDir, "testdbfile.db"))
@@ -3,6 +3,7 @@ package chaincmd
 import (
 	"fmt"
 
+	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner"
 	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
 	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
 )
@@ -22,6 +23,7 @@ const (
 	commandUnsafeReset       = "unsafe-reset-all"
 	commandExport            = "export"
 	commandTendermint        = "tendermint"
+	commandGenesis           = "genesis"
 
 	optionHome                             = "--home"
 	optionNode                             = "--node"
@@ -46,8 +48,16 @@ const (
 	optionCoinType                         = "--coin-type"
 	optionVestingAmount                    = "--vesting-amount"
 	optionVestingEndTime                   = "--vesting-end-time"
+	optionVestingStartTime                 = "--vesting-start-time"
 	optionBroadcastMode                    = "--broadcast-mode"
 
+	commandCosmovisor    = "cosmovisor"
+	commandCosmovisorRun = "run"
+
+	envDaemonName                = "DAEMON_NAME"
+	envDaemonHome                = "DAEMON_HOME"
+	envDaemonRestartAfterUpgrade = "DAEMON_RESTART_AFTER_UPGRADE"
+
 	constTendermint = "tendermint"
 	constJSON       = "json"
 	constSync       = "sync"
@@ -74,6 +84,8 @@ type ChainCmd struct {
 	cliHome         string
 	nodeAddress     string
 	legacySend      bool
+	cosmovisor      bool
+	container       *Container
 
 	isAutoChainIDDetectionEnabled bool
 
@@ -183,11 +195,55 @@ func WithLegacySendCommand() Option {
 	}
 }
 
+// WithCosmovisor runs the start command of the daemon under cosmovisor.
+// The daemon binaries must be laid out in the cosmovisor directory of the home.
+func WithCosmovisor() Option {
+	return func(c *ChainCmd) {
+		c.cosmovisor = true
+	}
+}
+
+// Container configures the container that runs the daemon commands.
+type Container struct {
+	// Runtime is the Docker compatible container runtime, e.g. docker or podman.
+	Runtime string
+
+	// Image is the image the containers are created from.
+	Image string
+
+	// Name is the name of the container that runs the start command.
+	// Other commands run in unnamed containers.
+	Name string
+
+	// User is the uid:gid the commands run as, so files written to the volumes
+	// are owned by the host user.
+	User string
+
+	// Volumes are host paths mounted in the container at the same paths.
+	Volumes []string
+}
+
+// WithContainer runs the daemon commands in containers that are removed when the
+// commands exit. The containers use the host network so the node ports are
+// reachable as if the daemon was running on the host.
+// The daemon binary must be available in the image or in one of the volumes.
+func WithContainer(container Container) Option {
+	return func(c *ChainCmd) {
+		c.container = &container
+	}
+}
+
 // StartCommand returns the command to start the daemon of the chain
 func (c ChainCmd) StartCommand(options ...string) step.Option {
 	command := append([]string{
 		commandStart,
 	}, options...)
+	if c.cosmovisor {
+		return c.cosmovisorCommand(command)
+	}
+	if c.container != nil {
+		return c.containerCommand(c.container.Name, c.attachHome(command))
+	}
 	return c.daemonCommand(command)
 }
 
@@ -281,11 +337,31 @@ func (c ChainCmd) AddGenesisAccountCommand(address, coins string) step.Option {
 		coins,
 	}
 
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
-// AddVestingAccountCommand returns the command to add a delayed vesting account in the genesis file of the chain
-func (c ChainCmd) AddVestingAccountCommand(address, originalCoins, vestingCoins string, vestingEndTime int64) step.Option {
+// VestingAccountOption for the AddVestingAccountCommand
+type VestingAccountOption func([]string) []string
+
+// VestingWithStartTime provides the start time of a continuous vesting account,
+// the account is a delayed vesting account when the start time is not set
+func VestingWithStartTime(vestingStartTime int64) VestingAccountOption {
+	return func(command []string) []string {
+		if vestingStartTime != 0 {
+			return append(command, optionVestingStartTime, fmt.Sprintf("%d", vestingStartTime))
+		}
+		return command
+	}
+}
+
+// AddVestingAccountCommand returns the command to add a delayed or continuous vesting account in the genesis file of the chain
+func (c ChainCmd) AddVestingAccountCommand(
+	address,
+	originalCoins,
+	vestingCoins string,
+	vestingEndTime int64,
+	options ...VestingAccountOption,
+) step.Option {
 	command := []string{
 		commandAddGenesisAccount,
 		address,
@@ -296,7 +372,11 @@ func (c ChainCmd) AddVestingAccountCommand(address, originalCoins, vestingCoins
 		fmt.Sprintf("%d", vestingEndTime),
 	}
 
-	return c.daemonCommand(command)
+	for _, apply := range options {
+		command = apply(command)
+	}
+
+	return c.genesisCommand(command)
 }
 
 // GentxOption for the GentxCommand
@@ -458,7 +538,7 @@ func (c ChainCmd) GentxCommand(
 
 	command = c.attachKeyringBackend(command)
 
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // CollectGentxsCommand returns the command to gather the gentxs in /gentx dir into the genesis file of the chain
@@ -466,7 +546,7 @@ func (c ChainCmd) CollectGentxsCommand() step.Option {
 	command := []string{
 		commandCollectGentxs,
 	}
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // ValidateGenesisCommand returns the command to check the validity of the chain genesis
@@ -474,7 +554,7 @@ func (c ChainCmd) ValidateGenesisCommand() step.Option {
 	command := []string{
 		commandValidateGenesis,
 	}
-	return c.daemonCommand(command)
+	return c.genesisCommand(command)
 }
 
 // ShowNodeIDCommand returns the command to print the node ID of the node for the chain
@@ -650,15 +730,58 @@ func (c ChainCmd) isStargate() bool {
 
 // daemonCommand returns the daemon command from the provided command
 func (c ChainCmd) daemonCommand(command []string) step.Option {
+	if c.container != nil {
+		return c.containerCommand("", c.attachHome(command))
+	}
 	return step.Exec(c.appCmd, c.attachHome(command)...)
 }
 
+// containerCommand returns the command to run the daemon with args in a container named name.
+func (c ChainCmd) containerCommand(name string, args []string) step.Option {
+	command := []string{"run", "--rm", "-i", "--network", "host"}
+	if name != "" {
+		command = append(command, "--name", name)
+	}
+	if c.container.User != "" {
+		command = append(command, "--user", c.container.User)
+	}
+	for _, volume := range c.container.Volumes {
+		command = append(command, "-v", fmt.Sprintf("%s:%s", volume, volume))
+	}
+	command = append(command, c.container.Image, c.appCmd)
+	command = append(command, args...)
+
+	return step.Exec(c.container.Runtime, command...)
+}
+
+// genesisCommand returns the daemon command from the provided genesis command.
+// Since Cosmos SDK 0.47 the genesis commands are grouped under the genesis subcommand.
+func (c ChainCmd) genesisCommand(command []string) step.Option {
+	if c.sdkVersion.GTE(cosmosver.StargateFortySevenVersion) {
+		command = append([]string{commandGenesis}, command...)
+	}
+	return c.daemonCommand(command)
+}
+
+// cosmovisorCommand returns the command to run the daemon command under cosmovisor
+func (c ChainCmd) cosmovisorCommand(command []string) step.Option {
+	command = append([]string{commandCosmovisorRun}, c.attachHome(command)...)
+	return func(s *step.Step) {
+		step.Exec(commandCosmovisor, command...)(s)
+		step.Env(
+			cmdrunner.Env(envDaemonName, c.appCmd),
+			cmdrunner.Env(envDaemonHome, c.homeDir),
+			cmdrunner.Env(envDaemonRestartAfterUpgrade, "true"),
+		)(s)
+	}
+}
+
 // cliCommand returns the cli command from the provided command
 // cli is the daemon for Stargate
 func (c ChainCmd) cliCommand(command []string) step.Option {
 	// Check version
 	if c.isStargate() {
-		return step.Exec(c.appCmd, c.attachHome(command)...)
+		return c.daemonCommand(command)
 	}
 	return step.Exec(c.cliCmd, c.attachCLIHome(command)...)
 }
@@ -0,0 +1,133 @@
+package chaincmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
+)
+
+func TestGenesisCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		version cosmosver.Version
+		want    []string
+	}{
+		{
+			name:    "0.45",
+			version: cosmosver.StargateFortyFiveThreeVersion,
+			want:    []string{"collect-gentxs"},
+		},
+		{
+			name:    "0.46",
+			version: cosmosver.StargateFortySixVersion,
+			want:    []string{"collect-gentxs"},
+		},
+		{
+			name:    "0.47",
+			version: cosmosver.StargateFortySevenVersion,
+			want:    []string{"genesis", "collect-gentxs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("marsd", WithVersion(tt.version))
+			s := step.New(c.CollectGentxsCommand())
+
+			require.Equal(t, "marsd", s.Exec.Command)
+			require.Equal(t, tt.want, s.Exec.Args)
+		})
+	}
+}
+
+func TestSubmitUpgradeProposalCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		version cosmosver.Version
+		want    []string
+	}{
+		{
+			name:    "0.45",
+			version: cosmosver.StargateFortyFiveThreeVersion,
+			want:    []string{"tx", "gov", "submit-proposal", "software-upgrade", "v2", "--title", "v2", "--description", "v2"},
+		},
+		{
+			name:    "0.46",
+			version: cosmosver.StargateFortySixVersion,
+			want:    []string{"tx", "gov", "submit-legacy-proposal", "software-upgrade", "v2", "--title", "v2", "--description", "v2"},
+		},
+		{
+			name:    "0.47",
+			version: cosmosver.StargateFortySevenVersion,
+			want:    []string{"tx", "upgrade", "software-upgrade", "v2", "--title", "v2", "--summary", "v2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New("marsd", WithVersion(tt.version))
+			s := step.New(c.SubmitUpgradeProposalCommand("alice", "v2", 10, "10stake"))
+
+			want := append(tt.want,
+				"--upgrade-height", "10",
+				"--deposit", "10stake",
+				"--from", "alice",
+				"--broadcast-mode", "sync",
+				"--yes",
+			)
+			require.Equal(t, want, s.Exec.Args)
+		})
+	}
+}
+
+func TestAddVestingAccountCommand(t *testing.T) {
+	c := New("marsd")
+
+	s := step.New(c.AddVestingAccountCommand("cosmos1abc", "1000token", "500token", 200))
+	require.Equal(t, []string{
+		"add-genesis-account", "cosmos1abc", "1000token",
+		"--vesting-amount", "500token", "--vesting-end-time", "200",
+	}, s.Exec.Args)
+
+	s = step.New(c.AddVestingAccountCommand("cosmos1abc", "1000token", "500token", 200, VestingWithStartTime(100)))
+	require.Equal(t, []string{
+		"add-genesis-account", "cosmos1abc", "1000token",
+		"--vesting-amount", "500token", "--vesting-end-time", "200",
+		"--vesting-start-time", "100",
+	}, s.Exec.Args)
+}
+
+func TestContainerCommands(t *testing.T) {
+	c := New("marsd", WithHome("/home/mars"), WithContainer(Container{
+		Runtime: "docker",
+		Image:   "mars-toolchain",
+		Name:    "mars-node",
+		User:    "1000:1000",
+		Volumes: []string{"/home/mars", "/bin/mars"},
+	}))
+
+	s := step.New(c.StartCommand())
+	require.Equal(t, "docker", s.Exec.Command)
+	require.Equal(t, []string{
+		"run", "--rm", "-i", "--network", "host",
+		"--name", "mars-node",
+		"--user", "1000:1000",
+		"-v", "/home/mars:/home/mars",
+		"-v", "/bin/mars:/bin/mars",
+		"mars-toolchain", "marsd",
+		"start", "--home", "/home/mars",
+	}, s.Exec.Args)
+
+	s = step.New(c.ValidateGenesisCommand())
+	require.Equal(t, []string{
+		"run", "--rm", "-i", "--network", "host",
+		"--user", "1000:1000",
+		"-v", "/home/mars:/home/mars",
+		"-v", "/bin/mars:/bin/mars",
+		"mars-toolchain", "marsd",
+		"validate-genesis", "--home", "/home/mars",
+	}, s.Exec.Args)
+}
@@ -0,0 +1,132 @@
+package chaincmd
+
+import (
+	"strconv"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
+)
+
+const (
+	commandGov            = "gov"
+	commandUpgrade        = "upgrade"
+	commandSubmitProposal = "submit-proposal"
+	commandSubmitLegacy   = "submit-legacy-proposal"
+	commandVote           = "vote"
+	commandProposals      = "proposals"
+	commandApplied        = "applied"
+
+	proposalTypeSoftwareUpgrade = "software-upgrade"
+
+	optionFrom          = "--from"
+	optionTitle         = "--title"
+	optionDescription   = "--description"
+	optionSummary       = "--summary"
+	optionUpgradeHeight = "--upgrade-height"
+	optionDeposit       = "--deposit"
+
+	// VoteOptionYes is the option to vote yes on a proposal.
+	VoteOptionYes = "yes"
+)
+
+// SubmitUpgradeProposalCommand returns the command to submit a software upgrade
+// governance proposal named name that halts the chain at height.
+// Cosmos SDK 0.46 moved the proposal to the legacy submit command and 0.47 moved it
+// to the upgrade module.
+func (c ChainCmd) SubmitUpgradeProposalCommand(fromAccount, name string, height int64, deposit string) step.Option {
+	var command []string
+
+	switch {
+	case c.sdkVersion.GTE(cosmosver.StargateFortySevenVersion):
+		command = []string{
+			commandTx,
+			commandUpgrade,
+			proposalTypeSoftwareUpgrade,
+			name,
+			optionTitle, name,
+			optionSummary, name,
+		}
+	case c.sdkVersion.GTE(cosmosver.StargateFortySixVersion):
+		command = []string{
+			commandTx,
+			commandGov,
+			commandSubmitLegacy,
+			proposalTypeSoftwareUpgrade,
+			name,
+			optionTitle, name,
+			optionDescription, name,
+		}
+	default:
+		command = []string{
+			commandTx,
+			commandGov,
+			commandSubmitProposal,
+			proposalTypeSoftwareUpgrade,
+			name,
+			optionTitle, name,
+			optionDescription, name,
+		}
+	}
+
+	command = append(command,
+		optionUpgradeHeight, strconv.FormatInt(height, 10),
+		optionDeposit, deposit,
+	)
+
+	return c.txCommand(command, fromAccount)
+}
+
+// VoteCommand returns the command to vote on a governance proposal.
+func (c ChainCmd) VoteCommand(fromAccount string, proposalID uint64, option string) step.Option {
+	command := []string{
+		commandTx,
+		commandGov,
+		commandVote,
+		strconv.FormatUint(proposalID, 10),
+		option,
+	}
+
+	return c.txCommand(command, fromAccount)
+}
+
+// QueryProposalsCommand returns the command to query governance proposals.
+func (c ChainCmd) QueryProposalsCommand() step.Option {
+	command := []string{
+		commandQuery,
+		commandGov,
+		commandProposals,
+		optionOutput, constJSON,
+	}
+
+	command = c.attachNode(command)
+	return c.cliCommand(command)
+}
+
+// QueryUpgradeAppliedCommand returns the command to query the block header of
+// the height at which the upgrade named name was applied.
+func (c ChainCmd) QueryUpgradeAppliedCommand(name string) step.Option {
+	command := []string{
+		commandQuery,
+		commandUpgrade,
+		commandApplied,
+		name,
+		optionOutput, constJSON,
+	}
+
+	command = c.attachNode(command)
+	return c.cliCommand(command)
+}
+
+// txCommand completes command with the flags to sign it with fromAccount and broadcast it.
+func (c ChainCmd) txCommand(command []string, fromAccount string) step.Option {
+	command = append(command,
+		optionFrom, fromAccount,
+		optionBroadcastMode, constSync,
+		optionYes,
+	)
+
+	command = c.attachChainID(command)
+	command = c.attachKeyringBackend(command)
+	command = c.attachNode(command)
+	return c.cliCommand(command)
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
 	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
 )
 
@@ -177,6 +178,7 @@ func (r Runner) AddVestingAccount(
 	originalCoins,
 	vestingCoins string,
 	vestingEndTime int64,
+	options ...chaincmd.VestingAccountOption,
 ) error {
-	return r.run(ctx, runOptions{}, r.chainCmd.AddVestingAccountCommand(address, originalCoins, vestingCoins, vestingEndTime))
+	return r.run(ctx, runOptions{}, r.chainCmd.AddVestingAccountCommand(address, originalCoins, vestingCoins, vestingEndTime, options...))
 }
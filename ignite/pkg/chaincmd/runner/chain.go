@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,7 +25,7 @@ func (r Runner) Start(ctx context.Context, args ...string) error {
 	return r.run(
 		ctx,
 		runOptions{wrappedStdErrMaxLen: 50000},
-		r.chainCmd.StartCommand(args...),
+		r.chainCmd.StartCommand(append(args, r.startArgs...)...),
 	)
 }
 
@@ -114,7 +115,8 @@ func (r Runner) ShowNodeID(ctx context.Context) (nodeID string, err error) {
 
 // NodeStatus keeps info about node's status.
 type NodeStatus struct {
-	ChainID string
+	ChainID           string
+	LatestBlockHeight int64
 }
 
 // Status returns the node's status.
@@ -125,43 +127,59 @@ func (r Runner) Status(ctx context.Context) (NodeStatus, error) {
 		return NodeStatus{}, err
 	}
 
-	var chainID string
-
 	data, err := b.JSONEnsuredBytes()
 	if err != nil {
 		return NodeStatus{}, err
 	}
 
+	type nodeInfo struct {
+		Network string `json:"network"`
+	}
+	type syncInfo struct {
+		LatestBlockHeight string `json:"latest_block_height"`
+	}
+
+	var (
+		info nodeInfo
+		sync syncInfo
+	)
+
 	version := r.chainCmd.SDKVersion()
 	switch {
 	case version.GTE(cosmosver.StargateFortyVersion):
 		out := struct {
-			NodeInfo struct {
-				Network string `json:"network"`
-			} `json:"NodeInfo"`
+			NodeInfo nodeInfo `json:"NodeInfo"`
+			SyncInfo syncInfo `json:"SyncInfo"`
 		}{}
 
 		if err := json.Unmarshal(data, &out); err != nil {
 			return NodeStatus{}, err
 		}
 
-		chainID = out.NodeInfo.Network
+		info, sync = out.NodeInfo, out.SyncInfo
 	default:
 		out := struct {
-			NodeInfo struct {
-				Network string `json:"network"`
-			} `json:"node_info"`
+			NodeInfo nodeInfo `json:"node_info"`
+			SyncInfo syncInfo `json:"sync_info"`
 		}{}
 
 		if err := json.Unmarshal(data, &out); err != nil {
 			return NodeStatus{}, err
 		}
 
-		chainID = out.NodeInfo.Network
+		info, sync = out.NodeInfo, out.SyncInfo
+	}
+
+	var height int64
+	if sync.LatestBlockHeight != "" {
+		if height, err = strconv.ParseInt(sync.LatestBlockHeight, 10, 64); err != nil {
+			return NodeStatus{}, err
+		}
 	}
 
 	return NodeStatus{
-		ChainID: chainID,
+		ChainID:           info.Network,
+		LatestBlockHeight: height,
 	}, nil
 }
 
@@ -0,0 +1,120 @@
+package chaincmdrunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
+)
+
+// SubmitUpgradeProposal submits a software upgrade governance proposal named name
+// that halts the chain at height and returns the hash of the tx.
+func (r Runner) SubmitUpgradeProposal(ctx context.Context, fromAccount, name string, height int64, deposit string) (string, error) {
+	return r.runTx(ctx, r.chainCmd.SubmitUpgradeProposalCommand(fromAccount, name, height, deposit))
+}
+
+// Vote votes on a governance proposal and returns the hash of the tx.
+func (r Runner) Vote(ctx context.Context, fromAccount string, proposalID uint64, option string) (string, error) {
+	return r.runTx(ctx, r.chainCmd.VoteCommand(fromAccount, proposalID, option))
+}
+
+// LatestProposalID returns the ID of the latest governance proposal.
+func (r Runner) LatestProposalID(ctx context.Context) (uint64, error) {
+	b := newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.QueryProposalsCommand()); err != nil {
+		return 0, err
+	}
+
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	// the proposal ID is named proposal_id before SDK v0.46 and id since then.
+	var out struct {
+		Proposals []struct {
+			ProposalID string `json:"proposal_id"`
+			ID         string `json:"id"`
+		} `json:"proposals"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, p := range out.Proposals {
+		id := p.ProposalID
+		if id == "" {
+			id = p.ID
+		}
+
+		n, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid proposal id %q", id)
+		}
+		if n > latest {
+			latest = n
+		}
+	}
+
+	if latest == 0 {
+		return 0, errors.New("no proposals found")
+	}
+	return latest, nil
+}
+
+// UpgradeAppliedHeight returns the height at which the upgrade named name was applied.
+func (r Runner) UpgradeAppliedHeight(ctx context.Context, name string) (int64, error) {
+	b := newBuffer()
+	if err := r.run(ctx, runOptions{stdout: b}, r.chainCmd.QueryUpgradeAppliedCommand(name)); err != nil {
+		return 0, err
+	}
+
+	data, err := b.JSONEnsuredBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	var header struct {
+		Height string `json:"height"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, err
+	}
+	if header.Height == "" {
+		return 0, fmt.Errorf("upgrade %q is not applied", name)
+	}
+
+	return strconv.ParseInt(header.Height, 10, 64)
+}
+
+// runTx runs a tx command and returns the hash of the tx when it is accepted.
+func (r Runner) runTx(ctx context.Context, command step.Option) (string, error) {
+	b := newBuffer()
+	opt := []step.Option{command}
+
+	if r.chainCmd.KeyringPassword() != "" {
+		input := &bytes.Buffer{}
+		fmt.Fprintln(input, r.chainCmd.KeyringPassword())
+		opt = append(opt, step.Write(input.Bytes()))
+	}
+
+	if err := r.run(ctx, runOptions{stdout: b}, opt...); err != nil {
+		return "", err
+	}
+
+	txResult, err := decodeTxResult(b)
+	if err != nil {
+		return "", err
+	}
+
+	if txResult.Code > 0 {
+		return "", fmt.Errorf("tx failed (SDK code %d): %s", txResult.Code, txResult.RawLog)
+	}
+
+	return txResult.TxHash, nil
+}
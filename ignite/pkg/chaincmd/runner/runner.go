@@ -22,6 +22,7 @@ type Runner struct {
 	chainCmd                      chaincmd.ChainCmd
 	stdout, stderr                io.Writer
 	daemonLogPrefix, cliLogPrefix string
+	startArgs                     []string
 }
 
 // Option configures Runner.
@@ -48,6 +49,13 @@ func CLILogPrefix(prefix string) Option {
 	}
 }
 
+// StartArgs sets extra arguments appended to the start command of the daemon.
+func StartArgs(args ...string) Option {
+	return func(runner *Runner) {
+		runner.startArgs = args
+	}
+}
+
 // Stderr sets stderr for executed commands.
 func Stderr(w io.Writer) Option {
 	return func(runner *Runner) {
@@ -48,7 +48,12 @@ func Binary(binaryName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	f, err := os.Open(binaryPath)
+	return File(binaryPath)
+}
+
+// File returns SHA256 hash of the file at path
+func File(path string) (string, error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,141 @@
+package cosmosanalysis
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// stateKeyRe matches the names of the declarations defining store keys and
+	// key prefixes, e.g. StoreKey, MemStoreKey, PostKey or KeyPrefixPost.
+	stateKeyRe = regexp.MustCompile(`^(ModuleName|StoreKey|MemStoreKey|TStoreKey)$|Key$|KeyPrefix|Prefix$`)
+
+	// storeKeysFuncs are the functions apps use to create their store keys.
+	storeKeysFuncs = map[string]bool{
+		"NewKVStoreKeys":        true,
+		"NewTransientStoreKeys": true,
+		"NewMemoryStoreKeys":    true,
+	}
+)
+
+// consensusVersionFunc is the module method returning the version of the
+// module state, incremented when a migration is added.
+const consensusVersionFunc = "ConsensusVersion"
+
+// StateDeclarations returns the source of the Go declarations of the module at
+// path that define the layout of the chain state:
+//
+//   - types with Genesis in their name, including generated ones.
+//   - constants, variables and functions defining store keys and key prefixes.
+//   - ConsensusVersion methods of the modules.
+//   - calls creating the store keys of the app.
+//
+// Each declaration is prefixed by the directory of its file, relative to path,
+// and the declarations are sorted so the result only changes when one of them
+// changes. Comments and formatting are ignored. Test files, testdata, vendor and
+// hidden directories are skipped.
+func StateDeclarations(path string) ([]string, error) {
+	var decls []string
+
+	err := filepath.WalkDir(path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := d.Name()
+		if d.IsDir() {
+			if filePath != path && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		dir, err := filepath.Rel(path, filepath.Dir(filePath))
+		if err != nil {
+			return err
+		}
+
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, filePath, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range stateNodes(f) {
+			var b bytes.Buffer
+			if err := printer.Fprint(&b, fset, node); err != nil {
+				return err
+			}
+			decls = append(decls, filepath.ToSlash(dir)+": "+b.String())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(decls)
+	return decls, nil
+}
+
+// stateNodes returns the nodes of f defining the layout of the chain state.
+func stateNodes(f *ast.File) (nodes []ast.Node) {
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if strings.Contains(spec.Name.Name, "Genesis") {
+						nodes = append(nodes, spec)
+					}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if stateKeyRe.MatchString(name.Name) {
+							nodes = append(nodes, spec)
+							break
+						}
+					}
+				}
+			}
+
+		case *ast.FuncDecl:
+			if decl.Name.Name == consensusVersionFunc || stateKeyRe.MatchString(decl.Name.Name) {
+				nodes = append(nodes, decl)
+			}
+		}
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		var name string
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			name = fun.Sel.Name
+		case *ast.Ident:
+			name = fun.Name
+		}
+		if storeKeysFuncs[name] {
+			nodes = append(nodes, call)
+		}
+		return true
+	})
+
+	return nodes
+}
@@ -0,0 +1,107 @@
+package cosmosanalysis_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosanalysis"
+)
+
+var (
+	keysFile = `package types
+
+const (
+	ModuleName = "mars"
+	StoreKey   = ModuleName
+	RouterKey  = ModuleName
+)
+
+const PostKey = "Post-value-"
+
+func KeyPrefix(p string) []byte { return []byte(p) }
+`
+
+	genesisFile = `package types
+
+// GenesisState defines the mars module's genesis state.
+type GenesisState struct {
+	PostList []Post
+}
+
+func (gs GenesisState) Validate() error { return nil }
+`
+
+	keeperFile = `package keeper
+
+func (k Keeper) GetPost(id uint64) Post { return Post{} }
+`
+
+	moduleFile = `package mars
+
+func (AppModule) ConsensusVersion() uint64 { return 2 }
+`
+
+	appStoreKeysFile = `package app
+
+func New() {
+	keys := sdk.NewKVStoreKeys(authtypes.StoreKey, marstypes.StoreKey)
+	_ = keys
+}
+`
+)
+
+func writeStateFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestStateDeclarations(t *testing.T) {
+	dir := t.TempDir()
+	writeStateFiles(t, dir, map[string]string{
+		"x/mars/types/keys.go":    keysFile,
+		"x/mars/types/genesis.go": genesisFile,
+		"x/mars/keeper/post.go":   keeperFile,
+		"x/mars/module.go":        moduleFile,
+		"app/app.go":              appStoreKeysFile,
+		"vendor/foo/genesis.go":   genesisFile,
+	})
+
+	decls, err := cosmosanalysis.StateDeclarations(dir)
+	require.NoError(t, err)
+
+	joined := strings.Join(decls, "\n")
+	require.Contains(t, joined, "x/mars/types: ModuleName")
+	require.Contains(t, joined, "x/mars/types: PostKey")
+	require.Contains(t, joined, "x/mars/types: func KeyPrefix")
+	require.Contains(t, joined, "x/mars/types: GenesisState struct")
+	require.Contains(t, joined, "x/mars: func (AppModule) ConsensusVersion()")
+	require.Contains(t, joined, "app: sdk.NewKVStoreKeys(authtypes.StoreKey, marstypes.StoreKey)")
+	require.NotContains(t, joined, "GetPost")
+	require.NotContains(t, joined, "Validate")
+	require.NotContains(t, joined, "vendor")
+
+	// keeper logic, comments and formatting don't change the declarations.
+	writeStateFiles(t, dir, map[string]string{
+		"x/mars/keeper/post.go":   keeperFile + "\nfunc (k Keeper) RemovePost(id uint64) {}\n",
+		"x/mars/types/genesis.go": strings.Replace(genesisFile, "PostList []Post", "PostList   []Post // posts", 1),
+	})
+	unchanged, err := cosmosanalysis.StateDeclarations(dir)
+	require.NoError(t, err)
+	require.Equal(t, decls, unchanged)
+
+	// store keys do.
+	writeStateFiles(t, dir, map[string]string{
+		"x/mars/types/keys.go": strings.Replace(keysFile, "Post-value-", "Post/value/", 1),
+	})
+	changed, err := cosmosanalysis.StateDeclarations(dir)
+	require.NoError(t, err)
+	require.NotEqual(t, decls, changed)
+}
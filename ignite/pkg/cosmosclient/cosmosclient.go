@@ -124,6 +124,14 @@ func WithNodeAddress(addr string) Option {
 	}
 }
 
+// WithChainID sets the chain id of your chain. When this option is provided, New doesn't query
+// the node for the chain id, so the client can be created before the node is started.
+func WithChainID(chainID string) Option {
+	return func(c *Client) {
+		c.chainID = chainID
+	}
+}
+
 func WithAddressPrefix(prefix string) Option {
 	return func(c *Client) {
 		c.addressPrefix = prefix
@@ -165,12 +173,14 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		return Client{}, err
 	}
 
-	statusResp, err := c.RPC.Status(ctx)
-	if err != nil {
-		return Client{}, err
-	}
+	if c.chainID == "" {
+		statusResp, err := c.RPC.Status(ctx)
+		if err != nil {
+			return Client{}, err
+		}
 
-	c.chainID = statusResp.NodeInfo.Network
+		c.chainID = statusResp.NodeInfo.Network
+	}
 
 	if c.homePath == "" {
 		home, err := os.UserHomeDir()
@@ -390,6 +400,99 @@ func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Ms
 	}, nil
 }
 
+// AccountNumberSequence returns the account number and the next sequence of accountName on chain.
+func (c Client) AccountNumberSequence(_ context.Context, accountName string) (accountNumber, sequence uint64, err error) {
+	mconf.Lock()
+	defer mconf.Unlock()
+	config := sdktypes.GetConfig()
+	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
+
+	accountAddress, err := c.Address(accountName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return c.context.AccountRetriever.GetAccountNumberSequence(c.context, accountAddress)
+}
+
+// BroadcastTxSync signs msgs for accountName with the given account number and sequence and broadcasts
+// the tx in sync mode: it returns once the tx is accepted in the mempool, without waiting for a block.
+// Callers that manage the sequence of the account locally can use it to send a tx without querying the account.
+func (c Client) BroadcastTxSync(
+	ctx context.Context,
+	accountName string,
+	accountNumber,
+	sequence uint64,
+	msgs ...sdktypes.Msg,
+) (*sdktypes.TxResponse, error) {
+	mconf.Lock()
+	defer mconf.Unlock()
+	config := sdktypes.GetConfig()
+	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
+
+	accountAddress, err := c.Address(accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCtx := c.context.
+		WithFromName(accountName).
+		WithFromAddress(accountAddress)
+
+	txf := c.Factory.
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence)
+
+	_, gas, err := tx.CalculateGas(clientCtx, txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	// the simulated gas can vary from the actual gas needed for a real transaction
+	// we add an additional amount to endure sufficient gas is provided
+	txf = txf.WithGas(gas + 10000)
+
+	txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(txf, accountName, txUnsigned, true); err != nil {
+		return nil, err
+	}
+
+	txBytes, err := clientCtx.TxConfig.TxEncoder()(txUnsigned.GetTx())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.RPC.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return sdktypes.NewResponseFormatBroadcastTx(res), nil
+}
+
+// WaitTx waits until the tx with txHash is included in a block and returns its response.
+func (c Client) WaitTx(ctx context.Context, txHash string) (*sdktypes.TxResponse, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *ctypes.ResultTx
+	err = backoff.Retry(func() (err error) {
+		res, err = c.RPC.Tx(ctx, hash, false)
+		return err
+	}, backoff.WithContext(backoff.NewConstantBackOff(time.Second), ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	return sdktypes.NewResponseResultTx(res, nil, ""), nil
+}
+
 // prepareBroadcast performs checks and operations before broadcasting messages
 func (c *Client) prepareBroadcast(ctx context.Context, accountName string, _ []sdktypes.Msg) error {
 	// TODO uncomment after https://github.com/tendermint/spn/issues/363
@@ -516,6 +619,7 @@ func newContext(
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
 	sdktypes.RegisterInterfaces(interfaceRegistry)
 	staking.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
 
 	return client.Context{}.
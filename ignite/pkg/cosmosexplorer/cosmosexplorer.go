@@ -0,0 +1,430 @@
+// Package cosmosexplorer is a lightweight block explorer for local Cosmos SDK chains.
+// It lists blocks, transactions with their decoded messages and events, accounts and
+// their balances, backed by the RPC and API servers of a node.
+package cosmosexplorer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultBlocksLimit is the default number of blocks listed per request.
+	DefaultBlocksLimit = 20
+
+	// maxBlocksLimit is the maximum number of blocks the RPC returns per request.
+	maxBlocksLimit = 20
+
+	// requestTimeout is the timeout of the requests to the node.
+	requestTimeout = 10 * time.Second
+)
+
+// Explorer reads the chain data from the RPC and API servers of a node.
+type Explorer struct {
+	rpcAddress string
+	apiAddress string
+	client     *http.Client
+}
+
+// Option configures the explorer.
+type Option func(*Explorer)
+
+// HTTPClient sets the client used to connect to the node.
+func HTTPClient(client *http.Client) Option {
+	return func(e *Explorer) {
+		e.client = client
+	}
+}
+
+// New creates a new explorer for the node at rpcAddress and apiAddress,
+// e.g. http://localhost:26657 and http://localhost:1317.
+func New(rpcAddress, apiAddress string, options ...Option) Explorer {
+	e := Explorer{
+		rpcAddress: strings.TrimSuffix(rpcAddress, "/"),
+		apiAddress: strings.TrimSuffix(apiAddress, "/"),
+		client:     &http.Client{Timeout: requestTimeout},
+	}
+
+	for _, apply := range options {
+		apply(&e)
+	}
+
+	return e
+}
+
+// Status is the status of the chain.
+type Status struct {
+	ChainID           string    `json:"chain_id"`
+	Moniker           string    `json:"moniker"`
+	LatestBlockHeight int64     `json:"latest_block_height"`
+	LatestBlockTime   time.Time `json:"latest_block_time"`
+}
+
+// BlockSummary is a block in the block list.
+type BlockSummary struct {
+	Height   int64     `json:"height"`
+	Hash     string    `json:"hash"`
+	Time     time.Time `json:"time"`
+	NumTxs   int64     `json:"num_txs"`
+	Proposer string    `json:"proposer"`
+}
+
+// Block is a block with the hashes of its transactions and the events emitted
+// at the beginning and the end of the block.
+type Block struct {
+	BlockSummary
+
+	Txs              []TxResult `json:"txs"`
+	BeginBlockEvents []Event    `json:"begin_block_events"`
+	EndBlockEvents   []Event    `json:"end_block_events"`
+}
+
+// TxResult is the result of a transaction in a block.
+type TxResult struct {
+	Hash   string  `json:"hash"`
+	Code   uint32  `json:"code"`
+	Log    string  `json:"log"`
+	Events []Event `json:"events"`
+}
+
+// Event is an ABCI event.
+type Event struct {
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Attribute is an attribute of an event.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Status returns the status of the chain.
+func (e Explorer) Status(ctx context.Context) (Status, error) {
+	var res struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+			Moniker string `json:"moniker"`
+		} `json:"node_info"`
+		SyncInfo struct {
+			LatestBlockHeight string    `json:"latest_block_height"`
+			LatestBlockTime   time.Time `json:"latest_block_time"`
+		} `json:"sync_info"`
+	}
+
+	if err := e.rpc(ctx, "status", nil, &res); err != nil {
+		return Status{}, err
+	}
+
+	height, err := strconv.ParseInt(res.SyncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{
+		ChainID:           res.NodeInfo.Network,
+		Moniker:           res.NodeInfo.Moniker,
+		LatestBlockHeight: height,
+		LatestBlockTime:   res.SyncInfo.LatestBlockTime,
+	}, nil
+}
+
+// Blocks returns up to limit blocks, latest first, starting at height maxHeight.
+// The latest blocks are returned when maxHeight is zero.
+func (e Explorer) Blocks(ctx context.Context, maxHeight int64, limit int) ([]BlockSummary, error) {
+	if limit <= 0 || limit > maxBlocksLimit {
+		limit = DefaultBlocksLimit
+	}
+
+	if maxHeight <= 0 {
+		status, err := e.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		maxHeight = status.LatestBlockHeight
+	}
+
+	minHeight := maxHeight - int64(limit) + 1
+	if minHeight < 1 {
+		minHeight = 1
+	}
+
+	var res struct {
+		BlockMetas []struct {
+			BlockID struct {
+				Hash string `json:"hash"`
+			} `json:"block_id"`
+			Header rpcHeader `json:"header"`
+			NumTxs string    `json:"num_txs"`
+		} `json:"block_metas"`
+	}
+
+	params := url.Values{
+		"minHeight": {strconv.FormatInt(minHeight, 10)},
+		"maxHeight": {strconv.FormatInt(maxHeight, 10)},
+	}
+	if err := e.rpc(ctx, "blockchain", params, &res); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]BlockSummary, 0, len(res.BlockMetas))
+	for _, meta := range res.BlockMetas {
+		numTxs, _ := strconv.ParseInt(meta.NumTxs, 10, 64)
+		blocks = append(blocks, meta.Header.summary(meta.BlockID.Hash, numTxs))
+	}
+
+	return blocks, nil
+}
+
+// Block returns the block at height with the results of its transactions.
+func (e Explorer) Block(ctx context.Context, height int64) (Block, error) {
+	params := url.Values{"height": {strconv.FormatInt(height, 10)}}
+
+	var block struct {
+		BlockID struct {
+			Hash string `json:"hash"`
+		} `json:"block_id"`
+		Block struct {
+			Header rpcHeader `json:"header"`
+			Data   struct {
+				Txs []string `json:"txs"`
+			} `json:"data"`
+		} `json:"block"`
+	}
+	if err := e.rpc(ctx, "block", params, &block); err != nil {
+		return Block{}, err
+	}
+
+	var results struct {
+		TxsResults []struct {
+			Code   uint32     `json:"code"`
+			Log    string     `json:"log"`
+			Events []rpcEvent `json:"events"`
+		} `json:"txs_results"`
+		BeginBlockEvents []rpcEvent `json:"begin_block_events"`
+		EndBlockEvents   []rpcEvent `json:"end_block_events"`
+	}
+	if err := e.rpc(ctx, "block_results", params, &results); err != nil {
+		return Block{}, err
+	}
+
+	txs := block.Block.Data.Txs
+	b := Block{
+		BlockSummary:     block.Block.Header.summary(block.BlockID.Hash, int64(len(txs))),
+		Txs:              make([]TxResult, 0, len(txs)),
+		BeginBlockEvents: decodeEvents(results.BeginBlockEvents),
+		EndBlockEvents:   decodeEvents(results.EndBlockEvents),
+	}
+
+	for i, tx := range txs {
+		hash, err := TxHash(tx)
+		if err != nil {
+			return Block{}, err
+		}
+
+		result := TxResult{Hash: hash}
+		if i < len(results.TxsResults) {
+			result.Code = results.TxsResults[i].Code
+			result.Log = results.TxsResults[i].Log
+			result.Events = decodeEvents(results.TxsResults[i].Events)
+		}
+		b.Txs = append(b.Txs, result)
+	}
+
+	return b, nil
+}
+
+// Tx returns the transaction with hash as returned by the API, including its
+// messages decoded with the interface registry of the app and its events.
+func (e Explorer) Tx(ctx context.Context, hash string) (json.RawMessage, error) {
+	return e.api(ctx, "/cosmos/tx/v1beta1/txs/"+url.PathEscape(hash), nil)
+}
+
+// TxsByEvents returns the latest transactions matching all the events,
+// e.g. message.sender='cosmos1...'.
+func (e Explorer) TxsByEvents(ctx context.Context, events []string) (json.RawMessage, error) {
+	params := url.Values{
+		"events":           events,
+		"order_by":         {"ORDER_BY_DESC"},
+		"pagination.limit": {strconv.Itoa(DefaultBlocksLimit)},
+	}
+	return e.api(ctx, "/cosmos/tx/v1beta1/txs", params)
+}
+
+// Accounts returns the accounts of the chain.
+func (e Explorer) Accounts(ctx context.Context) (json.RawMessage, error) {
+	return e.api(ctx, "/cosmos/auth/v1beta1/accounts", nil)
+}
+
+// Account returns the account with address and its balances.
+func (e Explorer) Account(ctx context.Context, address string) (account, balances json.RawMessage, err error) {
+	account, err = e.api(ctx, "/cosmos/auth/v1beta1/accounts/"+url.PathEscape(address), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balances, err = e.api(ctx, "/cosmos/bank/v1beta1/balances/"+url.PathEscape(address), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return account, balances, nil
+}
+
+// TxHash returns the hash of a base64 encoded transaction as shown by the RPC and the API.
+func TxHash(tx string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(tx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%X", sha256.Sum256(data)), nil
+}
+
+// NodeError is returned when the node responds to a request with an error.
+type NodeError struct {
+	Status  int
+	Message string
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("node responded with status %d: %s", e.Status, e.Message)
+}
+
+type rpcHeader struct {
+	Height          string    `json:"height"`
+	Time            time.Time `json:"time"`
+	ProposerAddress string    `json:"proposer_address"`
+}
+
+func (h rpcHeader) summary(hash string, numTxs int64) BlockSummary {
+	height, _ := strconv.ParseInt(h.Height, 10, 64)
+	return BlockSummary{
+		Height:   height,
+		Hash:     hash,
+		Time:     h.Time,
+		NumTxs:   numTxs,
+		Proposer: h.ProposerAddress,
+	}
+}
+
+type rpcEvent struct {
+	Type       string `json:"type"`
+	Attributes []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"attributes"`
+}
+
+// decodeEvents converts RPC events to events. Tendermint 0.34 encodes the keys and
+// values of the attributes in base64, they are decoded when they decode to text.
+func decodeEvents(events []rpcEvent) []Event {
+	decoded := make([]Event, 0, len(events))
+	for _, e := range events {
+		event := Event{Type: e.Type}
+		for _, attr := range e.Attributes {
+			event.Attributes = append(event.Attributes, Attribute{
+				Key:   decodeAttribute(attr.Key),
+				Value: decodeAttribute(attr.Value),
+			})
+		}
+		decoded = append(decoded, event)
+	}
+	return decoded
+}
+
+func decodeAttribute(value string) string {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || !utf8.Valid(data) {
+		return value
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) {
+			return value
+		}
+	}
+	return string(data)
+}
+
+// rpc calls the RPC method with params and decodes its result into result.
+func (e Explorer) rpc(ctx context.Context, method string, params url.Values, result interface{}) error {
+	data, err := e.get(ctx, e.rpcAddress+"/"+method, params)
+	if err != nil {
+		return err
+	}
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return &NodeError{Status: http.StatusBadRequest, Message: strings.TrimSpace(res.Error.Message + " " + res.Error.Data)}
+	}
+
+	return json.Unmarshal(res.Result, result)
+}
+
+// api requests path from the API.
+func (e Explorer) api(ctx context.Context, path string, params url.Values) (json.RawMessage, error) {
+	return e.get(ctx, e.apiAddress+path, params)
+}
+
+func (e Explorer) get(ctx context.Context, address string, params url.Values) ([]byte, error) {
+	if len(params) > 0 {
+		address += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var body struct {
+			Message string `json:"message"`
+			Error   struct {
+				Message string `json:"message"`
+				Data    string `json:"data"`
+			} `json:"error"`
+		}
+		message := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &body) == nil {
+			switch {
+			case body.Message != "":
+				message = body.Message
+			case body.Error.Message != "":
+				message = strings.TrimSpace(body.Error.Message + " " + body.Error.Data)
+			}
+		}
+		return nil, &NodeError{Status: res.StatusCode, Message: message}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,158 @@
+package cosmosexplorer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func newTestNode(t *testing.T) (rpc, api *httptest.Server) {
+	tx := b64("tx")
+
+	rpc = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result string
+		switch r.URL.Path {
+		case "/status":
+			result = `{"node_info":{"network":"mars","moniker":"mynode"},"sync_info":{"latest_block_height":"42","latest_block_time":"2022-01-01T00:00:00Z"}}`
+		case "/blockchain":
+			require.Equal(t, "23", r.URL.Query().Get("minHeight"))
+			require.Equal(t, "42", r.URL.Query().Get("maxHeight"))
+			result = `{"block_metas":[{"block_id":{"hash":"AB"},"header":{"height":"42","time":"2022-01-01T00:00:00Z","proposer_address":"P"},"num_txs":"1"}]}`
+		case "/block":
+			if r.URL.Query().Get("height") != "42" {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"height must be less than or equal to the current blockchain height"}}`))
+				return
+			}
+			result = `{"block_id":{"hash":"AB"},"block":{"header":{"height":"42","time":"2022-01-01T00:00:00Z","proposer_address":"P"},"data":{"txs":["` + tx + `"]}}}`
+		case "/block_results":
+			result = `{"txs_results":[{"code":0,"log":"[]","events":[{"type":"message","attributes":[{"key":"` + b64("sender") + `","value":"` + b64("cosmos1abc") + `"}]}]}],"begin_block_events":[{"type":"mint","attributes":[{"key":"amount","value":"10"}]}],"end_block_events":null}`
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":` + result + `}`))
+	}))
+	t.Cleanup(rpc.Close)
+
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/cosmos/tx/v1beta1/txs/"):
+			w.Write([]byte(`{"tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}}`))
+		case r.URL.Path == "/cosmos/auth/v1beta1/accounts/cosmos1abc":
+			w.Write([]byte(`{"account":{"address":"cosmos1abc"}}`))
+		case r.URL.Path == "/cosmos/bank/v1beta1/balances/cosmos1abc":
+			w.Write([]byte(`{"balances":[{"denom":"token","amount":"10"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":5,"message":"not found"}`))
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	return rpc, api
+}
+
+func TestExplorer(t *testing.T) {
+	rpc, api := newTestNode(t)
+	e := New(rpc.URL, api.URL+"/")
+	ctx := context.Background()
+
+	status, err := e.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "mars", status.ChainID)
+	require.Equal(t, int64(42), status.LatestBlockHeight)
+
+	blocks, err := e.Blocks(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, BlockSummary{
+		Height:   42,
+		Hash:     "AB",
+		Time:     status.LatestBlockTime,
+		NumTxs:   1,
+		Proposer: "P",
+	}, blocks[0])
+
+	block, err := e.Block(ctx, 42)
+	require.NoError(t, err)
+	hash, err := TxHash(b64("tx"))
+	require.NoError(t, err)
+	require.Equal(t, []TxResult{{
+		Hash: hash,
+		Log:  "[]",
+		Events: []Event{{
+			Type:       "message",
+			Attributes: []Attribute{{Key: "sender", Value: "cosmos1abc"}},
+		}},
+	}}, block.Txs)
+	require.Equal(t, []Event{{
+		Type:       "mint",
+		Attributes: []Attribute{{Key: "amount", Value: "10"}},
+	}}, block.BeginBlockEvents)
+	require.Empty(t, block.EndBlockEvents)
+
+	tx, err := e.Tx(ctx, hash)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}}`, string(tx))
+
+	account, balances, err := e.Account(ctx, "cosmos1abc")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"account":{"address":"cosmos1abc"}}`, string(account))
+	require.JSONEq(t, `{"balances":[{"denom":"token","amount":"10"}]}`, string(balances))
+}
+
+func TestExplorerErrors(t *testing.T) {
+	rpc, api := newTestNode(t)
+	e := New(rpc.URL, api.URL)
+	ctx := context.Background()
+
+	_, err := e.Block(ctx, 100)
+	var nodeErr *NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	require.Equal(t, http.StatusBadRequest, nodeErr.Status)
+	require.Contains(t, nodeErr.Message, "height must be less than")
+
+	_, _, err = e.Account(ctx, "cosmos1unknown")
+	require.ErrorAs(t, err, &nodeErr)
+	require.Equal(t, &NodeError{Status: http.StatusNotFound, Message: "not found"}, nodeErr)
+}
+
+func TestServeHTTP(t *testing.T) {
+	rpc, api := newTestNode(t)
+	s := httptest.NewServer(New(rpc.URL, api.URL))
+	defer s.Close()
+
+	res, err := http.Get(s.URL + "/api/accounts/cosmos1unknown")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Contains(t, body.Error.Message, "not found")
+
+	res, err = http.Get(s.URL + "/api/blocks?limit=invalid")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Get(s.URL + "/")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Contains(t, res.Header.Get("Content-Type"), "text/html")
+}
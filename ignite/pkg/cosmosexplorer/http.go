@@ -0,0 +1,167 @@
+package cosmosexplorer
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/cors"
+
+	"github.com/ignite-hq/cli/ignite/pkg/xhttp"
+)
+
+//go:embed index.html
+var index embed.FS
+
+// AccountResponse is the payload of the account endpoint.
+type AccountResponse struct {
+	Account  json.RawMessage `json:"account"`
+	Balances json.RawMessage `json:"balances"`
+}
+
+// ServeHTTP implements http.Handler to serve the explorer page and the JSON
+// endpoints it uses:
+//
+//	GET /api/status
+//	GET /api/blocks?before=<height>&limit=<n>
+//	GET /api/blocks/{height}
+//	GET /api/txs?events=<event>
+//	GET /api/txs/{hash}
+//	GET /api/accounts
+//	GET /api/accounts/{address}
+func (e Explorer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	api := router.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/status", e.statusHandler).Methods(http.MethodGet)
+	api.HandleFunc("/blocks", e.blocksHandler).Methods(http.MethodGet)
+	api.HandleFunc("/blocks/{height:[0-9]+}", e.blockHandler).Methods(http.MethodGet)
+	api.HandleFunc("/txs", e.txsHandler).Methods(http.MethodGet)
+	api.HandleFunc("/txs/{hash}", e.txHandler).Methods(http.MethodGet)
+	api.HandleFunc("/accounts", e.accountsHandler).Methods(http.MethodGet)
+	api.HandleFunc("/accounts/{address}", e.accountHandler).Methods(http.MethodGet)
+
+	router.Handle("/", http.FileServer(http.FS(index))).Methods(http.MethodGet)
+
+	cors.Default().Handler(router).ServeHTTP(w, r)
+}
+
+func (e Explorer) statusHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := e.Status(r.Context())
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, status)
+}
+
+func (e Explorer) blocksHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		before int64
+		limit  int
+		err    error
+	)
+
+	query := r.URL.Query()
+	if v := query.Get("before"); v != "" {
+		if before, err = strconv.ParseInt(v, 10, 64); err != nil {
+			xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("invalid before height")))
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("invalid limit")))
+			return
+		}
+	}
+
+	blocks, err := e.Blocks(r.Context(), before, limit)
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, blocks)
+}
+
+func (e Explorer) blockHandler(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseInt(mux.Vars(r)["height"], 10, 64)
+	if err != nil {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("invalid height")))
+		return
+	}
+
+	block, err := e.Block(r.Context(), height)
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, block)
+}
+
+func (e Explorer) txsHandler(w http.ResponseWriter, r *http.Request) {
+	events := r.URL.Query()["events"]
+	if len(events) == 0 {
+		xhttp.ResponseJSON(w, http.StatusBadRequest, xhttp.NewErrorResponse(errors.New("at least one event is required")))
+		return
+	}
+
+	txs, err := e.TxsByEvents(r.Context(), events)
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	responseRaw(w, txs)
+}
+
+func (e Explorer) txHandler(w http.ResponseWriter, r *http.Request) {
+	tx, err := e.Tx(r.Context(), mux.Vars(r)["hash"])
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	responseRaw(w, tx)
+}
+
+func (e Explorer) accountsHandler(w http.ResponseWriter, r *http.Request) {
+	accounts, err := e.Accounts(r.Context())
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	responseRaw(w, accounts)
+}
+
+func (e Explorer) accountHandler(w http.ResponseWriter, r *http.Request) {
+	account, balances, err := e.Account(r.Context(), mux.Vars(r)["address"])
+	if err != nil {
+		responseError(w, err)
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, AccountResponse{
+		Account:  account,
+		Balances: balances,
+	})
+}
+
+// responseError writes err with the status of the node response when the node
+// responded with an error, or with a bad gateway status when it cannot be reached.
+func responseError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+
+	var nodeErr *NodeError
+	if errors.As(err, &nodeErr) {
+		status = nodeErr.Status
+	}
+
+	xhttp.ResponseJSON(w, status, xhttp.NewErrorResponse(err))
+}
+
+func responseRaw(w http.ResponseWriter, data json.RawMessage) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
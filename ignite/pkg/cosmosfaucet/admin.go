@@ -0,0 +1,295 @@
+package cosmosfaucet
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+
+	"github.com/ignite-hq/cli/ignite/pkg/xhttp"
+)
+
+const (
+	// defaultTransfersLimit is the number of transfers listed by the admin API by default.
+	defaultTransfersLimit = 50
+
+	// maxTransfersLimit is the maximum number of transfers listed by the admin API.
+	maxTransfersLimit = 1000
+
+	// bearerPrefix is the scheme of the admin token in the Authorization header.
+	bearerPrefix = "Bearer "
+)
+
+var (
+	// ErrPaused is returned when a transfer is requested while the faucet is paused.
+	ErrPaused = errors.New("faucet is paused")
+
+	// ErrAddressBlocked is returned when a transfer is requested for a blocked address.
+	ErrAddressBlocked = errors.New("address is blocked")
+
+	errUnauthorized     = errors.New("invalid admin token")
+	errLedgerNotEnabled = errors.New("faucet has no ledger")
+	errEmptyCoins       = errors.New("coins cannot be empty")
+	errAmountTooLarge   = errors.New("amount is too large")
+)
+
+// settings are the settings of the faucet that can be changed at runtime with the admin API.
+// They're shared by the copies of the faucet.
+type settings struct {
+	mu       sync.RWMutex
+	paused   bool
+	coins    sdk.Coins
+	coinsMax map[string]uint64
+	blocked  map[string]bool
+}
+
+func newSettings(coins sdk.Coins, coinsMax map[string]uint64) *settings {
+	s := &settings{
+		coins:    coins,
+		coinsMax: make(map[string]uint64),
+		blocked:  make(map[string]bool),
+	}
+	for denom, max := range coinsMax {
+		s.coinsMax[denom] = max
+	}
+	return s
+}
+
+// defaultCoins returns the coins sent per request when a request doesn't specify coins.
+func (s *settings) defaultCoins() sdk.Coins {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(sdk.Coins{}, s.coins...)
+}
+
+// maxAmount returns the max amount of denom sent to an address, zero when there is no max.
+func (s *settings) maxAmount(denom string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.coinsMax[denom]
+}
+
+// check returns an error when transfers to address are not allowed.
+func (s *settings) check(address string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.paused {
+		return ErrPaused
+	}
+	if s.blocked[address] {
+		return ErrAddressBlocked
+	}
+	return nil
+}
+
+// AdminSettingsResponse is the payload of the settings of the faucet returned by the admin API.
+type AdminSettingsResponse struct {
+	Paused   bool     `json:"paused"`
+	Coins    []string `json:"coins"`
+	CoinsMax []string `json:"coins_max"`
+	Blocked  []string `json:"blocked"`
+}
+
+func (s *settings) response() AdminSettingsResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := AdminSettingsResponse{
+		Paused:   s.paused,
+		Coins:    []string{},
+		CoinsMax: []string{},
+		Blocked:  []string{},
+	}
+	for _, c := range s.coins {
+		res.Coins = append(res.Coins, c.String())
+	}
+	for denom, max := range s.coinsMax {
+		if max != 0 {
+			res.CoinsMax = append(res.CoinsMax, sdk.NewCoin(denom, sdk.NewIntFromUint64(max)).String())
+		}
+	}
+	for address := range s.blocked {
+		res.Blocked = append(res.Blocked, address)
+	}
+	sort.Strings(res.CoinsMax)
+	sort.Strings(res.Blocked)
+
+	return res
+}
+
+// AdminCoinsRequest changes the coins of the faucet, the coins that are not set are left unchanged.
+type AdminCoinsRequest struct {
+	// Coins are the coins sent per request when a request doesn't specify coins, they can't be empty.
+	Coins []string `json:"coins,omitempty"`
+
+	// CoinsMax replaces the max amounts sent to each address, the denoms without
+	// a max amount are not limited.
+	CoinsMax []string `json:"coins_max,omitempty"`
+}
+
+// adminRouter adds the routes of the admin API to router, they require the admin token.
+func (f Faucet) adminRouter(router *mux.Router) {
+	admin := router.PathPrefix("/admin").Subrouter()
+	admin.Use(f.adminAuth)
+
+	admin.HandleFunc("/settings", f.adminSettingsHandler).
+		Methods(http.MethodGet)
+
+	admin.HandleFunc("/pause", f.adminPauseHandler(true)).
+		Methods(http.MethodPost)
+
+	admin.HandleFunc("/resume", f.adminPauseHandler(false)).
+		Methods(http.MethodPost)
+
+	admin.HandleFunc("/coins", f.adminCoinsHandler).
+		Methods(http.MethodPut)
+
+	admin.HandleFunc("/blocked/{address}", f.adminBlockHandler(true)).
+		Methods(http.MethodPut)
+
+	admin.HandleFunc("/blocked/{address}", f.adminBlockHandler(false)).
+		Methods(http.MethodDelete)
+
+	admin.HandleFunc("/transfers", f.adminTransfersHandler).
+		Methods(http.MethodGet)
+}
+
+// adminAuth checks that requests have the admin token in their Authorization header.
+func (f Faucet) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(auth, bearerPrefix)
+		if !strings.HasPrefix(auth, bearerPrefix) ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(f.adminToken)) != 1 {
+			responseError(w, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f Faucet) adminSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	xhttp.ResponseJSON(w, http.StatusOK, f.settings.response())
+}
+
+func (f Faucet) adminPauseHandler(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.settings.mu.Lock()
+		f.settings.paused = paused
+		f.settings.mu.Unlock()
+
+		xhttp.ResponseJSON(w, http.StatusOK, f.settings.response())
+	}
+}
+
+func (f Faucet) adminCoinsHandler(w http.ResponseWriter, r *http.Request) {
+	var req AdminCoinsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	coins, err := sdk.ParseCoinsNormalized(strings.Join(req.Coins, ","))
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+	// empty coins would make the requests without coins send nothing.
+	if req.Coins != nil && coins.Empty() {
+		responseError(w, http.StatusBadRequest, errEmptyCoins)
+		return
+	}
+	coinsMax, err := sdk.ParseCoinsNormalized(strings.Join(req.CoinsMax, ","))
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+	for _, c := range coinsMax {
+		if !c.Amount.IsUint64() {
+			responseError(w, http.StatusBadRequest, fmt.Errorf("%w: %s", errAmountTooLarge, c))
+			return
+		}
+	}
+
+	f.settings.mu.Lock()
+	if req.Coins != nil {
+		f.settings.coins = coins
+	}
+	if req.CoinsMax != nil {
+		f.settings.coinsMax = make(map[string]uint64)
+		for _, c := range coinsMax {
+			f.settings.coinsMax[c.Denom] = c.Amount.Uint64()
+		}
+	}
+	f.settings.mu.Unlock()
+
+	xhttp.ResponseJSON(w, http.StatusOK, f.settings.response())
+}
+
+func (f Faucet) adminBlockHandler(blocked bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+
+		f.settings.mu.Lock()
+		if blocked {
+			f.settings.blocked[address] = true
+		} else {
+			delete(f.settings.blocked, address)
+		}
+		f.settings.mu.Unlock()
+
+		xhttp.ResponseJSON(w, http.StatusOK, f.settings.response())
+	}
+}
+
+// adminTransfersHandler lists the recent transfers of the faucet, the most recent first.
+// The transfers of a single address are listed with the address query parameter.
+func (f Faucet) adminTransfersHandler(w http.ResponseWriter, r *http.Request) {
+	if f.ledger == nil {
+		responseError(w, http.StatusNotFound, errLedgerNotEnabled)
+		return
+	}
+
+	limit := defaultTransfersLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			responseError(w, http.StatusBadRequest, errors.New("limit must be a positive integer"))
+			return
+		}
+		if limit > maxTransfersLimit {
+			limit = maxTransfersLimit
+		}
+	}
+
+	var (
+		transfers []Transfer
+		err       error
+	)
+	if address := r.URL.Query().Get("address"); address != "" {
+		transfers, err = f.ledger.transfersOf(address)
+		if len(transfers) > limit {
+			transfers = transfers[:limit]
+		}
+	} else {
+		transfers, err = f.ledger.recent(limit)
+	}
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if transfers == nil {
+		transfers = []Transfer{}
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, transfers)
+}
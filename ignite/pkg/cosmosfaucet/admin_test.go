@@ -0,0 +1,131 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+)
+
+func TestAdminAPI(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/cosmos/bank/v1beta1/balances/"+testAddress("faucet"), r.URL.Path)
+		io.WriteString(w, `{"balances":[{"denom":"token","amount":"990"}]}`)
+	}))
+	defer api.Close()
+
+	signer := newFakeSigner()
+	f := newBatchFaucet(t, signer, AdminToken("secret"), OpenAPI(api.URL), Coin(10, 0, "token"))
+
+	alice := testAddress("alice")
+	aliceRequest := `{"address":"` + alice + `"}`
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.RemoteAddr = "10.0.0.1:1234"
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, r)
+		return w
+	}
+	settings := func(w *httptest.ResponseRecorder) AdminSettingsResponse {
+		var res AdminSettingsResponse
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return res
+	}
+
+	// the admin token is required.
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/admin/settings", "", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/admin/settings", "wrong", "").Code)
+
+	// the token without the bearer scheme is rejected.
+	r := httptest.NewRequest(http.MethodGet, "/admin/settings", nil)
+	r.Header.Set("Authorization", "secret")
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	require.Equal(t, AdminSettingsResponse{
+		Coins:    []string{"10token"},
+		CoinsMax: []string{},
+		Blocked:  []string{},
+	}, settings(do(http.MethodGet, "/admin/settings", "secret", "")))
+
+	// transfers are denied while the faucet is paused.
+	require.True(t, settings(do(http.MethodPost, "/admin/pause", "secret", "")).Paused)
+	require.Equal(t, http.StatusServiceUnavailable, do(http.MethodPost, "/", "", aliceRequest).Code)
+	require.False(t, settings(do(http.MethodPost, "/admin/resume", "secret", "")).Paused)
+
+	// transfers to blocked addresses are denied.
+	res := settings(do(http.MethodPut, "/admin/blocked/"+alice, "secret", ""))
+	require.Equal(t, []string{alice}, res.Blocked)
+	require.Equal(t, http.StatusForbidden, do(http.MethodPost, "/", "", aliceRequest).Code)
+	res = settings(do(http.MethodDelete, "/admin/blocked/"+alice, "secret", ""))
+	require.Empty(t, res.Blocked)
+
+	// the amounts are changed at runtime.
+	res = settings(do(http.MethodPut, "/admin/coins", "secret", `{"coins":["20token"],"coins_max":["30token"]}`))
+	require.Equal(t, []string{"20token"}, res.Coins)
+	require.Equal(t, []string{"30token"}, res.CoinsMax)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/coins", "secret", `{"coins":["token"]}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/coins", "secret", `{"coins_max":["18446744073709551616token"]}`).Code)
+
+	// an empty list of coins is rejected, while omitted coins are left unchanged.
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/coins", "secret", `{"coins":[]}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/coins", "secret", `{"coins":[""]}`).Code)
+	res = settings(do(http.MethodPut, "/admin/coins", "secret", `{"coins_max":["30token"]}`))
+	require.Equal(t, []string{"20token"}, res.Coins)
+
+	signer.confirmed <- struct{}{}
+	w = do(http.MethodPost, "/", "", aliceRequest)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"tx_hash":"TX7"`)
+
+	w = do(http.MethodPost, "/", "", aliceRequest)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, w.Body.String(), "ask less amount")
+
+	// the recent transfers are listed.
+	w = do(http.MethodGet, "/admin/transfers?limit=10", "secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var transfers []Transfer
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&transfers))
+	require.Len(t, transfers, 1)
+	require.Equal(t, alice, transfers[0].Address)
+	require.Equal(t, "20token", transfers[0].Coins)
+
+	w = do(http.MethodGet, "/admin/transfers?address="+testAddress("bob"), "secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&transfers))
+	require.Empty(t, transfers)
+
+	// the metrics count the requests, denials, transferred amounts and the remaining balance.
+	w = do(http.MethodGet, "/metrics", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	metrics := w.Body.String()
+	require.Contains(t, metrics, "faucet_requests_total 4")
+	require.Contains(t, metrics, `faucet_denied_requests_total{reason="paused"} 1`)
+	require.Contains(t, metrics, `faucet_denied_requests_total{reason="blocked"} 1`)
+	require.Contains(t, metrics, `faucet_denied_requests_total{reason="max_amount"} 1`)
+	require.Contains(t, metrics, `faucet_transferred_amount_total{denom="token"} 20`)
+	require.Contains(t, metrics, `faucet_balance{account="faucet",denom="token"} 990`)
+}
+
+func TestAdminAPIDisabled(t *testing.T) {
+	f, err := New(context.Background(), chaincmdrunner.Runner{}, ChainID("test"))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/settings", nil))
+	require.NotEqual(t, http.StatusOK, w.Code)
+}
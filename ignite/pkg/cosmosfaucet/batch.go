@@ -0,0 +1,297 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+const (
+	// maxBatchSize is the maximum number of transfers sent in a single tx.
+	maxBatchSize = 100
+
+	// txTimeout is the time to wait for a tx to be included in a block.
+	txTimeout = 30 * time.Second
+)
+
+// Signer signs and broadcasts the txs of the faucet in-process.
+// cosmosclient.Client implements it.
+type Signer interface {
+	// AccountNumberSequence returns the account number and the next sequence of accountName on chain.
+	AccountNumberSequence(ctx context.Context, accountName string) (accountNumber, sequence uint64, err error)
+
+	// BroadcastTxSync signs msgs for accountName with the given account number and sequence and
+	// broadcasts the tx without waiting for it to be included in a block.
+	BroadcastTxSync(
+		ctx context.Context,
+		accountName string,
+		accountNumber,
+		sequence uint64,
+		msgs ...sdk.Msg,
+	) (*sdk.TxResponse, error)
+
+	// WaitTx waits until the tx with txHash is included in a block and returns its response.
+	WaitTx(ctx context.Context, txHash string) (*sdk.TxResponse, error)
+}
+
+// pendingTransfer is a transfer waiting to be sent in the next batch.
+type pendingTransfer struct {
+	address string
+	coins   sdk.Coins
+	result  chan transferResult
+}
+
+type transferResult struct {
+	txHash string
+	err    error
+}
+
+// reservations holds the coins of the transfers in progress that don't count in the transferred
+// amounts yet, so concurrent transfers to an address can't exceed its max amounts.
+type reservations struct {
+	mu       sync.Mutex
+	reserved map[string]sdk.Coins
+}
+
+func newReservations() *reservations {
+	return &reservations{
+		reserved: make(map[string]sdk.Coins),
+	}
+}
+
+// reserve calls check with the coins reserved for address and reserves coins for address
+// when check succeeds.
+func (r *reservations) reserve(address string, coins sdk.Coins, check func(reserved sdk.Coins) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := check(r.reserved[address]); err != nil {
+		return err
+	}
+	r.reserved[address] = r.reserved[address].Add(coins...)
+	return nil
+}
+
+// release releases the coins reserved by the transfers.
+func (r *reservations) release(transfers []pendingTransfer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.remove(transfers)
+}
+
+// settle calls record and releases the coins reserved by the transfers at once, so a concurrent
+// check never counts the transfers both as recorded and reserved.
+func (r *reservations) settle(transfers []pendingTransfer, record func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	defer r.remove(transfers)
+	return record()
+}
+
+func (r *reservations) remove(transfers []pendingTransfer) {
+	for _, t := range transfers {
+		remaining, hasNeg := r.reserved[t.address].SafeSub(t.coins)
+		if hasNeg || remaining.IsZero() {
+			delete(r.reserved, t.address)
+		} else {
+			r.reserved[t.address] = remaining
+		}
+	}
+}
+
+// batcher sends the transfers requested while the previous tx is waiting to be included in a block
+// in a single MsgMultiSend, so the faucet sends at most one tx per block whatever the number of requests.
+// The account number and sequence of the faucet account are fetched once and then managed locally.
+type batcher struct {
+	signer       Signer
+	accountName  string
+	ledger       *ledger
+	reservations *reservations
+
+	// fromAddress returns the address of the faucet account, it's overridden in tests.
+	fromAddress func(ctx context.Context) (string, error)
+
+	queue   chan pendingTransfer
+	stopped chan struct{}
+
+	// the fields below are only accessed by the run loop.
+	address                 string
+	accountNumber, sequence uint64
+	synced                  bool
+}
+
+func newBatcher(
+	signer Signer,
+	accountName string,
+	l *ledger,
+	r *reservations,
+	fromAddress func(context.Context) (string, error),
+) *batcher {
+	return &batcher{
+		signer:       signer,
+		accountName:  accountName,
+		ledger:       l,
+		reservations: r,
+		fromAddress:  fromAddress,
+		queue:        make(chan pendingTransfer, maxBatchSize),
+		stopped:      make(chan struct{}),
+	}
+}
+
+// transfer queues a transfer and waits until it's sent.
+func (b *batcher) transfer(ctx context.Context, address string, coins sdk.Coins) (txHash string, err error) {
+	t := pendingTransfer{
+		address: address,
+		coins:   coins,
+		result:  make(chan transferResult, 1),
+	}
+
+	select {
+	case b.queue <- t:
+	case <-b.stopped:
+		b.reservations.release([]pendingTransfer{t})
+		return "", errFaucetStopped
+	case <-ctx.Done():
+		b.reservations.release([]pendingTransfer{t})
+		return "", ctx.Err()
+	}
+
+	select {
+	case r := <-t.result:
+		return r.txHash, r.err
+	case <-b.stopped:
+		return "", errFaucetStopped
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// run sends the queued transfers until ctx is canceled.
+func (b *batcher) run(ctx context.Context) {
+	defer close(b.stopped)
+
+	for {
+		var batch []pendingTransfer
+
+		select {
+		case <-ctx.Done():
+			return
+		case t := <-b.queue:
+			batch = append(batch, t)
+		}
+
+		// add the transfers queued while the previous tx was waiting for a block.
+	collect:
+		for len(batch) < maxBatchSize {
+			select {
+			case t := <-b.queue:
+				batch = append(batch, t)
+			default:
+				break collect
+			}
+		}
+
+		txHash, err := b.send(ctx, batch)
+		for _, t := range batch {
+			t.result <- transferResult{txHash, err}
+		}
+	}
+}
+
+// send sends the batch in a single tx and waits until the tx is included in a block.
+func (b *batcher) send(ctx context.Context, batch []pendingTransfer) (txHash string, err error) {
+	released := false
+	defer func() {
+		if !released {
+			b.reservations.release(batch)
+		}
+	}()
+
+	if !b.synced {
+		if b.address, err = b.fromAddress(ctx); err != nil {
+			return "", err
+		}
+		if b.accountNumber, b.sequence, err = b.signer.AccountNumberSequence(ctx, b.accountName); err != nil {
+			return "", err
+		}
+		b.synced = true
+	}
+
+	resp, err := b.signer.BroadcastTxSync(ctx, b.accountName, b.accountNumber, b.sequence, multiSend(b.address, batch))
+	if err == nil && resp.Code != 0 {
+		err = fmt.Errorf("transfer failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	if err != nil {
+		// the sequence might be out of sync, e.g. after a tx sent from the faucet account
+		// outside the faucet, so it's fetched again for the next batch.
+		b.synced = false
+		return "", err
+	}
+	b.sequence++
+	txHash = resp.TxHash
+
+	// record the transfers as soon as they are broadcast, so they count in the limits even
+	// when waiting for their confirmation fails.
+	if b.ledger != nil {
+		released = true
+		err := b.reservations.settle(batch, func() error {
+			now := time.Now()
+			for _, t := range batch {
+				if err := b.ledger.record(Transfer{
+					Address: t.address,
+					Coins:   t.coins.String(),
+					TxHash:  txHash,
+					Time:    now,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return txHash, err
+		}
+	}
+
+	// wait for the tx before sending the next batch, the transfers requested meanwhile
+	// are sent together in the next tx.
+	waitCtx, cancel := context.WithTimeout(ctx, txTimeout)
+	defer cancel()
+
+	resp, err = b.signer.WaitTx(waitCtx, txHash)
+	if err != nil {
+		// the tx might have been dropped from the mempool.
+		b.synced = false
+		return txHash, err
+	}
+	if resp.Code != 0 {
+		return txHash, fmt.Errorf("transfer failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	return txHash, nil
+}
+
+// multiSend returns a MsgMultiSend sending the coins of the transfers from address.
+func multiSend(address string, transfers []pendingTransfer) *banktypes.MsgMultiSend {
+	msg := &banktypes.MsgMultiSend{}
+
+	var total sdk.Coins
+	for _, t := range transfers {
+		msg.Outputs = append(msg.Outputs, banktypes.Output{
+			Address: t.address,
+			Coins:   t.coins,
+		})
+		total = total.Add(t.coins...)
+	}
+	msg.Inputs = []banktypes.Input{{
+		Address: address,
+		Coins:   total,
+	}}
+
+	return msg
+}
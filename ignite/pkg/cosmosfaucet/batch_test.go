@@ -0,0 +1,246 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+)
+
+type sentTx struct {
+	sequence uint64
+	msg      *banktypes.MsgMultiSend
+}
+
+// fakeSigner records the broadcast txs, WaitTx blocks until a tx is confirmed with confirm.
+type fakeSigner struct {
+	mu        sync.Mutex
+	sequence  uint64
+	syncs     int
+	txs       []sentTx
+	broadcast chan struct{}
+	confirmed chan struct{}
+	failNext  bool
+}
+
+func newFakeSigner() *fakeSigner {
+	return &fakeSigner{
+		sequence:  7,
+		broadcast: make(chan struct{}, 10),
+		confirmed: make(chan struct{}, 10),
+	}
+}
+
+func (s *fakeSigner) AccountNumberSequence(context.Context, string) (uint64, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncs++
+	return 1, s.sequence, nil
+}
+
+func (s *fakeSigner) BroadcastTxSync(_ context.Context, _ string, _, sequence uint64, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer func() { s.broadcast <- struct{}{} }()
+
+	if s.failNext {
+		s.failNext = false
+		return nil, errors.New("account sequence mismatch")
+	}
+	if sequence != s.sequence {
+		return &sdk.TxResponse{Code: 32, RawLog: "account sequence mismatch"}, nil
+	}
+	s.sequence++
+	s.txs = append(s.txs, sentTx{sequence, msgs[0].(*banktypes.MsgMultiSend)})
+	return &sdk.TxResponse{TxHash: fmt.Sprintf("TX%d", sequence)}, nil
+}
+
+func (s *fakeSigner) WaitTx(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	select {
+	case <-s.confirmed:
+		return &sdk.TxResponse{TxHash: txHash}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// testAddress returns a bech32 address with the cosmos prefix derived from name.
+func testAddress(name string) string {
+	return sdk.AccAddress(name).String()
+}
+
+func (s *fakeSigner) sent() []sentTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sentTx(nil), s.txs...)
+}
+
+func newBatchFaucet(t *testing.T, signer Signer, options ...Option) Faucet {
+	storage, err := cache.NewStorage(filepath.Join(t.TempDir(), "ledger.db"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	options = append(options, ChainID("test"), NativeSigner(signer), LedgerStorage(storage))
+	f, err := New(ctx, chaincmdrunner.Runner{}, options...)
+	require.NoError(t, err)
+
+	f.pool.resolve = func(_ context.Context, name string) (string, error) {
+		return testAddress(name), nil
+	}
+	return f
+}
+
+func TestBatchTransfers(t *testing.T) {
+	signer := newFakeSigner()
+	f := newBatchFaucet(t, signer, Coin(10, 0, "token"))
+	ctx := context.Background()
+
+	type result struct {
+		address, txHash string
+		err             error
+	}
+	results := make(chan result, 4)
+	transfer := func(address string) {
+		txHash, err := f.Transfer(ctx, address, sdk.NewCoins(sdk.NewInt64Coin("token", 10)))
+		results <- result{address, txHash, err}
+	}
+
+	// the first transfer is sent alone, the next ones are queued while it waits for a block.
+	go transfer(testAddress("alice"))
+	<-signer.broadcast
+
+	for _, address := range []string{testAddress("bob"), testAddress("carol"), testAddress("dave")} {
+		go transfer(address)
+	}
+	require.Eventually(t, func() bool { return len(f.batchers[DefaultAccountName].queue) == 3 }, time.Second, time.Millisecond)
+
+	signer.confirmed <- struct{}{}
+	<-signer.broadcast
+	signer.confirmed <- struct{}{}
+
+	txHashes := make(map[string]string)
+	for i := 0; i < 4; i++ {
+		r := <-results
+		require.NoError(t, r.err)
+		txHashes[r.address] = r.txHash
+	}
+	require.Equal(t, map[string]string{
+		testAddress("alice"): "TX7",
+		testAddress("bob"):   "TX8",
+		testAddress("carol"): "TX8",
+		testAddress("dave"):  "TX8",
+	}, txHashes)
+
+	// the sequence is fetched once and incremented locally.
+	require.Equal(t, 1, signer.syncs)
+
+	txs := signer.sent()
+	require.Len(t, txs, 2)
+	require.Equal(t, uint64(8), txs[1].sequence)
+	require.Len(t, txs[1].msg.Outputs, 3)
+	require.Equal(t, []banktypes.Input{{
+		Address: testAddress("faucet"),
+		Coins:   sdk.NewCoins(sdk.NewInt64Coin("token", 30)),
+	}}, txs[1].msg.Inputs)
+
+	// the transfers are recorded in the ledger.
+	transfers, err := f.ledger.get(testAddress("bob"))
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, "TX8", transfers[0].TxHash)
+}
+
+func TestBatchTransfersResyncSequence(t *testing.T) {
+	signer := newFakeSigner()
+	signer.failNext = true
+	f := newBatchFaucet(t, signer)
+	ctx := context.Background()
+	coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+
+	_, err := f.Transfer(ctx, testAddress("alice"), coins)
+	require.Error(t, err)
+
+	// the account sequence is fetched again after a failed broadcast.
+	signer.confirmed <- struct{}{}
+	txHash, err := f.Transfer(ctx, testAddress("alice"), coins)
+	require.NoError(t, err)
+	require.Equal(t, "TX7", txHash)
+	require.Equal(t, 2, signer.syncs)
+}
+
+func TestBatchTransfersMaxAmount(t *testing.T) {
+	signer := newFakeSigner()
+	f := newBatchFaucet(t, signer, Coin(10, 25, "token"))
+	ctx := context.Background()
+	coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+
+	// transfers waiting to be sent count in the max amount.
+	errs := make(chan error, 2)
+	transfer := func() {
+		_, err := f.Transfer(ctx, testAddress("alice"), coins)
+		errs <- err
+	}
+	go transfer()
+	<-signer.broadcast
+	go transfer()
+	require.Eventually(t, func() bool { return len(f.batchers[DefaultAccountName].queue) == 1 }, time.Second, time.Millisecond)
+
+	_, err := f.Transfer(ctx, testAddress("alice"), coins)
+	require.ErrorContains(t, err, "ask less amount")
+
+	signer.confirmed <- struct{}{}
+	<-signer.broadcast
+	signer.confirmed <- struct{}{}
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+
+	// the sent transfers count in the max amount once recorded.
+	_, err = f.Transfer(ctx, testAddress("alice"), coins)
+	require.ErrorContains(t, err, "ask less amount")
+	require.Empty(t, f.reservations.reserved)
+}
+
+func TestBatchTransfersInvalidAddress(t *testing.T) {
+	signer := newFakeSigner()
+	f := newBatchFaucet(t, signer, Coin(10, 0, "token"))
+	ctx := context.Background()
+	coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+
+	wrongPrefix, err := bech32.ConvertAndEncode("osmo", []byte("bob"))
+	require.NoError(t, err)
+
+	// invalid addresses are rejected before being queued, so they don't fail the batch.
+	for _, address := range []string{"cosmos1bob", wrongPrefix, ""} {
+		_, err := f.Transfer(ctx, address, coins)
+		require.ErrorIs(t, err, ErrInvalidAddress)
+	}
+
+	signer.confirmed <- struct{}{}
+	txHash, err := f.Transfer(ctx, testAddress("alice"), coins)
+	require.NoError(t, err)
+	require.Equal(t, "TX7", txHash)
+
+	txs := signer.sent()
+	require.Len(t, txs, 1)
+	require.Len(t, txs[0].msg.Outputs, 1)
+
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"address":"cosmos1bob"}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,247 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultChallengeTTL is the time a client has to solve a proof-of-work challenge.
+const DefaultChallengeTTL = 5 * time.Minute
+
+// ErrChallengeFailed is returned when a transfer request doesn't solve the challenge
+// required by the faucet.
+type ErrChallengeFailed struct {
+	Reason string
+}
+
+// Error implements error.
+func (e ErrChallengeFailed) Error() string {
+	return fmt.Sprintf("challenge failed: %s", e.Reason)
+}
+
+// CaptchaVerifier verifies the captcha solved by a client before a transfer.
+type CaptchaVerifier interface {
+	// Verify returns an error when response isn't a valid captcha solution.
+	// remoteIP is the IP of the client that solved the captcha.
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// SiteVerifyCaptcha verifies captcha responses with a siteverify endpoint, as provided
+// by reCAPTCHA, hCaptcha and Cloudflare Turnstile.
+type SiteVerifyCaptcha struct {
+	verifyURL string
+	secret    string
+}
+
+// NewSiteVerifyCaptcha creates a captcha verifier that posts the responses to verifyURL
+// with the secret key of the site.
+func NewSiteVerifyCaptcha(verifyURL, secret string) SiteVerifyCaptcha {
+	return SiteVerifyCaptcha{
+		verifyURL: verifyURL,
+		secret:    secret,
+	}
+}
+
+// Verify implements CaptchaVerifier.
+func (c SiteVerifyCaptcha) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrChallengeFailed{"captcha response is required"}
+	}
+
+	form := url.Values{
+		"secret":   {c.secret},
+		"response": {response},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha verification: %s", resp.Status)
+	}
+
+	var out struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return err
+	}
+	if !out.Success {
+		reason := "invalid captcha"
+		if len(out.ErrorCodes) > 0 {
+			reason = fmt.Sprintf("%s: %s", reason, strings.Join(out.ErrorCodes, ", "))
+		}
+		return ErrChallengeFailed{reason}
+	}
+	return nil
+}
+
+// ChallengeResponse is a hashcash-style proof-of-work challenge.
+// To solve it, a client finds a nonce such that the SHA-256 hash of
+// "<challenge>:<address>:<nonce>" starts with Difficulty zero bits.
+// A challenge can be used for a single transfer until it expires.
+type ChallengeResponse struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// proofOfWork issues and verifies proof-of-work challenges.
+// Challenges are signed so that they don't need to be stored until they are used.
+type proofOfWork struct {
+	difficulty int
+	ttl        time.Duration
+	key        []byte
+
+	mu sync.Mutex
+	// used holds the expiry time of the challenges that were already used.
+	used map[string]time.Time
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+func newProofOfWork(difficulty int, ttl time.Duration) (*proofOfWork, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &proofOfWork{
+		difficulty: difficulty,
+		ttl:        ttl,
+		key:        key,
+		used:       make(map[string]time.Time),
+		now:        time.Now,
+	}, nil
+}
+
+// new issues a new challenge.
+func (p *proofOfWork) new() (ChallengeResponse, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	expiresAt := p.now().Add(p.ttl).Truncate(time.Second)
+	payload := fmt.Sprintf("%s.%d", hex.EncodeToString(random), expiresAt.Unix())
+
+	return ChallengeResponse{
+		Challenge:  fmt.Sprintf("%s.%s", payload, p.sign(payload)),
+		Difficulty: p.difficulty,
+		ExpiresAt:  expiresAt.UTC(),
+	}, nil
+}
+
+func (p *proofOfWork) sign(payload string) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks that nonce solves challenge for address and marks the challenge as used.
+func (p *proofOfWork) verify(challenge, address, nonce string) error {
+	if challenge == "" || nonce == "" {
+		return ErrChallengeFailed{"proof of work is required, solve a challenge from /challenge"}
+	}
+
+	i := strings.LastIndex(challenge, ".")
+	if i < 0 || !hmac.Equal([]byte(p.sign(challenge[:i])), []byte(challenge[i+1:])) {
+		return ErrChallengeFailed{"invalid challenge"}
+	}
+
+	parts := strings.Split(challenge[:i], ".")
+	if len(parts) != 2 {
+		return ErrChallengeFailed{"invalid challenge"}
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ErrChallengeFailed{"invalid challenge"}
+	}
+	expiresAt := time.Unix(expiry, 0)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for c, e := range p.used {
+		if !now.Before(e) {
+			delete(p.used, c)
+		}
+	}
+
+	if !now.Before(expiresAt) {
+		return ErrChallengeFailed{"challenge expired"}
+	}
+	if _, ok := p.used[challenge]; ok {
+		return ErrChallengeFailed{"challenge already used"}
+	}
+	if !hasLeadingZeroBits(challengeHash(challenge, address, nonce), p.difficulty) {
+		return ErrChallengeFailed{"invalid proof of work"}
+	}
+
+	p.used[challenge] = expiresAt
+
+	return nil
+}
+
+// SolveChallenge returns a nonce that solves challenge for address with difficulty.
+func SolveChallenge(challenge, address string, difficulty int) string {
+	for n := uint64(0); ; n++ {
+		nonce := strconv.FormatUint(n, 10)
+		if hasLeadingZeroBits(challengeHash(challenge, address, nonce), difficulty) {
+			return nonce
+		}
+	}
+}
+
+func challengeHash(challenge, address, nonce string) []byte {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%s", challenge, address, nonce)))
+	return sum[:]
+}
+
+// hasLeadingZeroBits returns true when hash starts with at least n zero bits.
+func hasLeadingZeroBits(hash []byte, n int) bool {
+	for _, b := range hash {
+		if n <= 0 {
+			return true
+		}
+		if n < 8 {
+			return bits.LeadingZeros8(b) >= n
+		}
+		if b != 0 {
+			return false
+		}
+		n -= 8
+	}
+	return n <= 0
+}
+
+// errChallengeDisabled is returned when a challenge is requested from a faucet
+// that doesn't require proof of work.
+var errChallengeDisabled = errors.New("proof of work is not enabled")
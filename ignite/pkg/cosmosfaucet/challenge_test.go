@@ -0,0 +1,71 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofOfWork(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p, err := newProofOfWork(8, time.Minute)
+	require.NoError(t, err)
+	p.now = func() time.Time { return now }
+
+	c, err := p.new()
+	require.NoError(t, err)
+	require.Equal(t, 8, c.Difficulty)
+
+	nonce := SolveChallenge(c.Challenge, "cosmos1alice", c.Difficulty)
+
+	// the solution is bound to the address.
+	require.Error(t, p.verify(c.Challenge, "cosmos1bob", nonce))
+	require.Error(t, p.verify(c.Challenge+"0", "cosmos1alice", nonce))
+	require.Error(t, p.verify("", "cosmos1alice", ""))
+
+	require.NoError(t, p.verify(c.Challenge, "cosmos1alice", nonce))
+	require.Equal(t, ErrChallengeFailed{"challenge already used"}, p.verify(c.Challenge, "cosmos1alice", nonce))
+
+	c, err = p.new()
+	require.NoError(t, err)
+	nonce = SolveChallenge(c.Challenge, "cosmos1alice", c.Difficulty)
+	now = now.Add(time.Minute)
+	require.Equal(t, ErrChallengeFailed{"challenge expired"}, p.verify(c.Challenge, "cosmos1alice", nonce))
+
+	// expired challenges are forgotten.
+	require.Empty(t, p.used)
+}
+
+func TestHasLeadingZeroBits(t *testing.T) {
+	require.True(t, hasLeadingZeroBits([]byte{0x00, 0x0f}, 12))
+	require.False(t, hasLeadingZeroBits([]byte{0x00, 0x0f}, 13))
+	require.True(t, hasLeadingZeroBits([]byte{0x10}, 3))
+	require.False(t, hasLeadingZeroBits([]byte{0x10}, 4))
+	require.True(t, hasLeadingZeroBits([]byte{0xff}, 0))
+}
+
+func TestSiteVerifyCaptcha(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "10.0.0.1", r.PostForm.Get("remoteip"))
+
+		if r.PostForm.Get("response") == "solved" {
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+		w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer ts.Close()
+
+	c := NewSiteVerifyCaptcha(ts.URL, "secret")
+	require.NoError(t, c.Verify(context.Background(), "solved", "10.0.0.1"))
+	require.Equal(t,
+		ErrChallengeFailed{"invalid captcha: invalid-input-response"},
+		c.Verify(context.Background(), "wrong", "10.0.0.1"),
+	)
+}
@@ -77,3 +77,25 @@ func (c HTTPClient) FaucetInfo(ctx context.Context) (FaucetInfoResponse, error)
 	err = json.NewDecoder(hres.Body).Decode(&res)
 	return res, err
 }
+
+// Challenge fetches a proof-of-work challenge to solve before a transfer with SolveChallenge.
+func (c HTTPClient) Challenge(ctx context.Context) (ChallengeResponse, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/challenge", nil)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return ChallengeResponse{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return ChallengeResponse{}, errors.New(http.StatusText(hres.StatusCode))
+	}
+
+	var res ChallengeResponse
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, err
+}
@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
 )
 
@@ -58,6 +59,66 @@ type Faucet struct {
 
 	// openAPIData 保存用於服務 OpenAPI 頁面和規範的模板數據自定義。
 	openAPIData openAPIData
+
+	// limiter 按 IP 和子網限制請求數量，在水龍頭的副本之間共享。
+	limiter *ipLimiter
+
+	// powDifficulty 是工作量證明挑戰的難度，為 0 時不需要工作量證明。
+	powDifficulty int
+
+	// pow 簽發和驗證工作量證明挑戰。
+	pow *proofOfWork
+
+	// captcha 在轉賬前驗證驗證碼，為 nil 時不需要驗證碼。
+	captcha CaptchaVerifier
+
+	// captchaSiteKey 是前端顯示驗證碼使用的站點密鑰。
+	captchaSiteKey string
+
+	// ledger 記錄水龍頭的轉賬，為 nil 時從鏈上的交易事件計算已轉賬數量。
+	ledger *ledger
+
+	// reconcile 表示使用賬本時同時查詢鏈上的交易事件，取兩者中較大的已轉賬數量。
+	reconcile bool
+
+	// signer 在進程內簽名和廣播轉賬交易，為 nil 時使用鏈的二進製文件逐個發送轉賬。
+	signer Signer
+
+	// batchers 為帳戶池中的每個帳戶將同一區塊內請求的轉賬合併到一個 MsgMultiSend 交易中，
+	// 在水龍頭的副本之間共享。
+	batchers map[string]*batcher
+
+	// reservations 保存正在進行的轉賬的數量，在所有帳戶的 batcher 之間共享。
+	reservations *reservations
+
+	// poolAccounts 是除 accountName 之外用於轉移代幣的帳戶。
+	poolAccounts []string
+
+	// pool 輪流選擇發送轉賬的帳戶。
+	pool *accountPool
+
+	// lowBalance 是帳戶餘額的下限，低於它時發出事件，為空時不檢查餘額。
+	lowBalance sdk.Coins
+
+	// treasuryAccount 是在帳戶餘額低於下限時為其補充 refillCoins 的帳戶，為空時不補充。
+	treasuryAccount string
+	refillCoins     sdk.Coins
+
+	// balanceCheckInterval 是檢查帳戶餘額的間隔。
+	balanceCheckInterval time.Duration
+
+	// events 接收水龍頭的事件。
+	events func(Event)
+
+	// settings 是可以通過管理 API 在運行時修改的設置，在水龍頭的副本之間共享。
+	// 它以 coins 和 coinsMax 初始化。
+	settings *settings
+
+	// adminToken 是訪問管理 API 的令牌，為空時不啟用管理 API。
+	adminToken string
+
+	// metrics 是 /metrics 端點暴露的 Prometheus 指標。
+	metrics *metrics
 }
 
 // Option 配置水龍頭選項。
@@ -73,6 +134,44 @@ func Account(name, mnemonic string, coinType string) Option {
 	}
 }
 
+// PoolAccounts 將帳戶添加到水龍頭的帳戶池中，轉賬輪流從池中的帳戶發送，
+// 每個帳戶有自己的序列號，避免單個帳戶成為瓶頸。帳戶必須存在於密鑰環中。
+func PoolAccounts(names ...string) Option {
+	return func(f *Faucet) {
+		f.poolAccounts = append(f.poolAccounts, names...)
+	}
+}
+
+// LowBalance 定期檢查帳戶池中的帳戶餘額，當任一面額的餘額低於 threshold 時發出 EventLowBalance 事件，
+// 餘額不足的帳戶在其他帳戶有足夠代幣時不再發送轉賬。
+func LowBalance(threshold sdk.Coins) Option {
+	return func(f *Faucet) {
+		f.lowBalance = threshold
+	}
+}
+
+// Refill 在帳戶的餘額低於 LowBalance 的下限時，從密鑰環中的 treasury 帳戶為其轉入 coins 中餘額不足的面額。
+func Refill(treasury string, coins sdk.Coins) Option {
+	return func(f *Faucet) {
+		f.treasuryAccount = treasury
+		f.refillCoins = coins
+	}
+}
+
+// BalanceCheckInterval 設置檢查帳戶餘額的間隔，默認為 DefaultBalanceCheckInterval。
+func BalanceCheckInterval(interval time.Duration) Option {
+	return func(f *Faucet) {
+		f.balanceCheckInterval = interval
+	}
+}
+
+// Events 使用 handler 接收水龍頭的事件，例如帳戶餘額不足和補充帳戶。
+func Events(handler func(Event)) Option {
+	return func(f *Faucet) {
+		f.events = handler
+	}
+}
+
 // Coin 將一個新的硬幣添加到硬幣列表中以通過水龍頭分發。
 // 添加到列表中的第一個硬幣在轉移請求期間被視為默認硬幣。
 //
@@ -93,6 +192,73 @@ func RefreshWindow(refreshWindow time.Duration) Option {
 	}
 }
 
+// IPRateLimit 限制在 window 內從每個 IP 接受的轉賬請求數量為 requests，
+// 從每個子網（IPv4 為 /24，IPv6 為 /64）接受的數量為 subnetRequests，為 0 時不限制。
+func IPRateLimit(requests, subnetRequests int, window time.Duration) Option {
+	return func(f *Faucet) {
+		f.limiter.ipRequests = requests
+		f.limiter.subnetRequests = subnetRequests
+		f.limiter.window = window
+	}
+}
+
+// TrustProxy 使用 X-Forwarded-For 頭中的最後一個地址，即可信的反向代理添加的地址作為客戶端 IP，
+// 之前的地址由客戶端發送，不可信。只應在水龍頭直接運行在一個可信的反向代理之後時使用。
+func TrustProxy() Option {
+	return func(f *Faucet) {
+		f.limiter.trustProxy = true
+	}
+}
+
+// ProofOfWork 要求客戶端在轉賬前解決難度為 difficulty 位前導零的工作量證明挑戰。
+func ProofOfWork(difficulty int) Option {
+	return func(f *Faucet) {
+		f.powDifficulty = difficulty
+	}
+}
+
+// Captcha 要求客戶端在轉賬前解決由 verifier 驗證的驗證碼，
+// siteKey 通過 /info 提供給前端用於顯示驗證碼。
+func Captcha(verifier CaptchaVerifier, siteKey string) Option {
+	return func(f *Faucet) {
+		f.captcha = verifier
+		f.captchaSiteKey = siteKey
+	}
+}
+
+// LedgerStorage 將水龍頭的轉賬記錄在 storage 的賬本中，並用賬本計算每個地址的已轉賬數量，
+// 不再在每次請求時查詢鏈上的所有歷史轉賬事件。
+func LedgerStorage(storage cache.Storage) Option {
+	return func(f *Faucet) {
+		f.ledger = newLedger(storage)
+	}
+}
+
+// Reconcile 在使用賬本時同時查詢鏈上的交易事件，取兩者中較大的已轉賬數量，
+// 用於賬本被清除或有不經過水龍頭的轉賬時。
+func Reconcile() Option {
+	return func(f *Faucet) {
+		f.reconcile = true
+	}
+}
+
+// NativeSigner 使用 signer 在進程內簽名轉賬交易，而不是調用鏈的二進製文件。
+// 並發的轉賬請求會被合併到每個區塊一個 MsgMultiSend 交易中，賬戶序列號在本地管理。
+// 轉賬在 New 的 ctx 被取消前發送。
+func NativeSigner(signer Signer) Option {
+	return func(f *Faucet) {
+		f.signer = signer
+	}
+}
+
+// AdminToken 啟用 /admin 下的管理 API，請求需要在 Authorization 頭中攜帶 "Bearer <token>"。
+// 管理 API 可以暫停和恢復水龍頭、修改每次發送的數量和最大數量、封禁地址以及列出最近的轉賬。
+func AdminToken(token string) Option {
+	return func(f *Faucet) {
+		f.adminToken = token
+	}
+}
+
 // ChainID 添加 chain id 去水龍頭。 faucet 將在未提供時自動獲取。
 func ChainID(id string) Option {
 	return func(f *Faucet) {
@@ -114,6 +280,7 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		accountName: DefaultAccountName,
 		coinsMax:    make(map[string]uint64),
 		openAPIData: openAPIData{"Blockchain", "http://localhost:1317"},
+		limiter:     newIPLimiter(),
 	}
 
 	for _, apply := range options {
@@ -128,6 +295,30 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		RefreshWindow(DefaultRefreshWindow)(&f)
 	}
 
+	if f.ledger != nil {
+		f.ledger.retention = f.limitRefreshWindow
+	}
+
+	f.settings = newSettings(f.coins, f.coinsMax)
+
+	runner, apiAddress := f.runner, f.openAPIData.APIAddress
+	f.pool = newAccountPool(append([]string{f.accountName}, f.poolAccounts...), func(ctx context.Context, name string) (string, error) {
+		account, err := runner.ShowAccount(ctx, name)
+		return account.Address, err
+	})
+	balance := func(ctx context.Context, address string) (sdk.Coins, error) {
+		return QueryBalance(ctx, apiAddress, address)
+	}
+	f.metrics = newMetrics(newBalanceCollector(f.pool, balance))
+
+	if f.powDifficulty > 0 {
+		pow, err := newProofOfWork(f.powDifficulty, DefaultChallengeTTL)
+		if err != nil {
+			return Faucet{}, err
+		}
+		f.pow = pow
+	}
+
 	// 如果提供助記詞，則導入帳戶.
 	if f.accountMnemonic != "" {
 		_, err := f.runner.AddAccount(ctx, f.accountName, f.accountMnemonic, f.coinType)
@@ -146,5 +337,35 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		f.openAPIData.ChainID = status.ChainID
 	}
 
+	f.reservations = newReservations()
+	if f.signer != nil {
+		f.batchers = make(map[string]*batcher)
+		for _, name := range f.pool.names {
+			name := name
+			b := newBatcher(f.signer, name, f.ledger, f.reservations, func(ctx context.Context) (string, error) {
+				return f.pool.address(ctx, name)
+			})
+			f.batchers[name] = b
+			go b.run(ctx)
+		}
+	}
+
+	if !f.lowBalance.Empty() {
+		if f.balanceCheckInterval == 0 {
+			f.balanceCheckInterval = DefaultBalanceCheckInterval
+		}
+		monitor := &balanceMonitor{
+			pool:      f.pool,
+			threshold: f.lowBalance,
+			interval:  f.balanceCheckInterval,
+			treasury:  f.treasuryAccount,
+			refill:    f.refillCoins,
+			events:    f.events,
+			balance:   balance,
+			send:      f.refill,
+		}
+		go monitor.run(ctx)
+	}
+
 	return f, nil
 }
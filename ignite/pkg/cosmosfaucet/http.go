@@ -20,6 +20,18 @@ func (f Faucet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	router.Handle("/info", cors.Default().Handler(http.HandlerFunc(f.faucetInfoHandler))).
 		Methods(http.MethodGet)
 
+	router.Handle("/challenge", cors.Default().Handler(http.HandlerFunc(f.challengeHandler))).
+		Methods(http.MethodGet)
+
+	if f.metrics != nil {
+		router.Handle("/metrics", f.metrics.handler()).
+			Methods(http.MethodGet)
+	}
+
+	if f.adminToken != "" {
+		f.adminRouter(router)
+	}
+
 	router.HandleFunc("/", openapiconsole.Handler("Faucet", "openapi.yml")).
 		Methods(http.MethodGet)
 
@@ -3,7 +3,10 @@ package cosmosfaucet
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -17,6 +20,14 @@ type TransferRequest struct {
 	// Coins that are requested.
 	// default ones used when this one isn't provided.
 	Coins []string `json:"coins"`
+
+	// Challenge and Nonce are the proof-of-work challenge from /challenge and its solution,
+	// required when the faucet requires proof of work.
+	Challenge string `json:"challenge,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+
+	// Captcha is the captcha response, required when the faucet requires a captcha.
+	Captcha string `json:"captcha,omitempty"`
 }
 
 func NewTransferRequest(accountAddress string, coins []string) TransferRequest {
@@ -27,33 +38,86 @@ func NewTransferRequest(accountAddress string, coins []string) TransferRequest {
 }
 
 type TransferResponse struct {
+	// TxHash is the hash of the tx that sent the coins.
+	// Transfers requested at the same time can be sent in the same tx.
+	TxHash string `json:"tx_hash,omitempty"`
+
 	Error string `json:"error,omitempty"`
 }
 
 func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
 	var req TransferRequest
 
+	f.metrics.request()
+
+	ip, err := f.limiter.clientIP(r)
+	if err != nil {
+		f.metrics.deny(denyInvalidRequest)
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// limit the requests of the client before doing any work for it.
+	if err := f.limiter.allow(ip); err != nil {
+		var rateErr ErrRateLimited
+		if errors.As(err, &rateErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+		}
+		f.metrics.deny(denyRateLimited)
+		responseError(w, http.StatusTooManyRequests, err)
+		return
+	}
+
 	// decode request into req.
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.metrics.deny(denyInvalidRequest)
 		responseError(w, http.StatusBadRequest, err)
 		return
 	}
 
+	// check the challenges required by the faucet.
+	if err := f.verifyChallenges(r.Context(), req, ip.String()); err != nil {
+		var challengeErr ErrChallengeFailed
+		if errors.As(err, &challengeErr) {
+			f.metrics.deny(denyChallengeFailed)
+			responseError(w, http.StatusForbidden, err)
+		} else {
+			responseError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
 	// determine coins to transfer.
 	coins, err := f.coinsFromRequest(req)
 	if err != nil {
+		f.metrics.deny(denyInvalidRequest)
 		responseError(w, http.StatusBadRequest, err)
 		return
 	}
 
 	// try performing the transfer
-	if err := f.Transfer(r.Context(), req.AccountAddress, coins); err != nil {
-		if err == context.Canceled {
-			return
-		}
+	txHash, err := f.Transfer(r.Context(), req.AccountAddress, coins)
+	var maxErr ErrMaxAmount
+	switch {
+	case err == nil:
+		f.metrics.transferred(coins)
+		responseSuccess(w, txHash)
+	case err == context.Canceled:
+	case errors.Is(err, ErrPaused):
+		f.metrics.deny(denyPaused)
+		responseError(w, http.StatusServiceUnavailable, err)
+	case errors.Is(err, ErrInvalidAddress):
+		f.metrics.deny(denyInvalidRequest)
+		responseError(w, http.StatusBadRequest, err)
+	case errors.Is(err, ErrAddressBlocked):
+		f.metrics.deny(denyBlocked)
+		responseError(w, http.StatusForbidden, err)
+	case errors.As(err, &maxErr):
+		f.metrics.deny(denyMaxAmount)
+		responseError(w, http.StatusInternalServerError, err)
+	default:
+		f.metrics.transferError()
 		responseError(w, http.StatusInternalServerError, err)
-	} else {
-		responseSuccess(w)
 	}
 }
 
@@ -65,19 +129,61 @@ type FaucetInfoResponse struct {
 
 	// ChainID is chain id of the chain that faucet is running for.
 	ChainID string `json:"chain_id"`
+
+	// ProofOfWork is the difficulty of the proof-of-work challenges required for transfers.
+	// Zero when no proof of work is required.
+	ProofOfWork int `json:"proof_of_work,omitempty"`
+
+	// Captcha indicates that a captcha is required for transfers.
+	Captcha bool `json:"captcha,omitempty"`
+
+	// CaptchaSiteKey is the site key used by frontends to show the captcha.
+	CaptchaSiteKey string `json:"captcha_site_key,omitempty"`
 }
 
 func (f Faucet) faucetInfoHandler(w http.ResponseWriter, r *http.Request) {
 	xhttp.ResponseJSON(w, http.StatusOK, FaucetInfoResponse{
-		IsAFaucet: true,
-		ChainID:   f.chainID,
+		IsAFaucet:      true,
+		ChainID:        f.chainID,
+		ProofOfWork:    f.powDifficulty,
+		Captcha:        f.captcha != nil,
+		CaptchaSiteKey: f.captchaSiteKey,
 	})
 }
 
+func (f Faucet) challengeHandler(w http.ResponseWriter, r *http.Request) {
+	if f.pow == nil {
+		responseError(w, http.StatusNotFound, errChallengeDisabled)
+		return
+	}
+
+	challenge, err := f.pow.new()
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+	xhttp.ResponseJSON(w, http.StatusOK, challenge)
+}
+
+// verifyChallenges checks that req solves the challenges required by the faucet.
+func (f Faucet) verifyChallenges(ctx context.Context, req TransferRequest, remoteIP string) error {
+	if f.pow != nil {
+		if err := f.pow.verify(req.Challenge, req.AccountAddress, req.Nonce); err != nil {
+			return err
+		}
+	}
+	if f.captcha != nil {
+		if err := f.captcha.Verify(ctx, req.Captcha, remoteIP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // coinsFromRequest determines tokens to transfer from transfer request.
 func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 	if len(req.Coins) == 0 {
-		return f.coins, nil
+		return f.settings.defaultCoins(), nil
 	}
 
 	var coins []sdk.Coin
@@ -92,8 +198,10 @@ func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 	return coins, nil
 }
 
-func responseSuccess(w http.ResponseWriter) {
-	xhttp.ResponseJSON(w, http.StatusOK, TransferResponse{})
+func responseSuccess(w http.ResponseWriter, txHash string) {
+	xhttp.ResponseJSON(w, http.StatusOK, TransferResponse{
+		TxHash: txHash,
+	})
 }
 
 func responseError(w http.ResponseWriter, code int, err error) {
@@ -0,0 +1,54 @@
+package cosmosfaucet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaucetHandlerChallenges(t *testing.T) {
+	pow, err := newProofOfWork(4, time.Minute)
+	require.NoError(t, err)
+
+	f := Faucet{limiter: newIPLimiter(), powDifficulty: 4, pow: pow}
+	IPRateLimit(2, 0, time.Hour)(&f)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, r)
+		return w
+	}
+
+	// the proof of work is required.
+	w := post(`{"address":"cosmos1alice"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/challenge", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var c ChallengeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&c))
+	require.Equal(t, 4, c.Difficulty)
+
+	nonce := SolveChallenge(c.Challenge, "cosmos1alice", 4)
+	w = post(`{"address":"cosmos1alice","challenge":"` + c.Challenge + `0","nonce":"` + nonce + `"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	// the client has sent too many requests.
+	w = post(`{"address":"cosmos1alice"}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "3600", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/info", nil))
+	var info FaucetInfoResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, FaucetInfoResponse{IsAFaucet: true, ProofOfWork: 4}, info)
+}
@@ -0,0 +1,144 @@
+package cosmosfaucet
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+)
+
+// ledgerNamespace is the cache namespace of the transfers recorded by the faucet.
+const ledgerNamespace = "faucet.ledger"
+
+// Transfer is a transfer sent by the faucet.
+type Transfer struct {
+	// Address is the address of the recipient.
+	Address string `json:"address"`
+
+	// Coins are the transferred coins, they are stored as a string because
+	// sdk.Coins can't be encoded with gob.
+	Coins string `json:"coins"`
+
+	// TxHash is the hash of the transfer transaction.
+	TxHash string `json:"tx_hash"`
+
+	// Time is the time of the transfer.
+	Time time.Time `json:"time"`
+}
+
+// ledger keeps the transfers of the faucet for each recipient address, so the maximum
+// amounts can be enforced without querying the tx events of the chain.
+// Transfers older than the retention are pruned when new transfers are recorded.
+type ledger struct {
+	transfers cache.Cache[[]Transfer]
+	retention time.Duration
+
+	// mu makes recording a transfer atomic, it's shared by the copies of the faucet.
+	mu *sync.Mutex
+}
+
+func newLedger(storage cache.Storage) *ledger {
+	return &ledger{
+		transfers: cache.New[[]Transfer](storage, ledgerNamespace),
+		mu:        &sync.Mutex{},
+	}
+}
+
+// record adds a transfer to the ledger.
+func (l *ledger) record(t Transfer) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transfers, err := l.get(t.Address)
+	if err != nil {
+		return err
+	}
+
+	// prune the transfers that don't count in the limits anymore.
+	if l.retention > 0 {
+		recent := transfers[:0]
+		for _, transfer := range transfers {
+			if t.Time.Sub(transfer.Time) < l.retention {
+				recent = append(recent, transfer)
+			}
+		}
+		transfers = recent
+	}
+
+	return l.transfers.Put(t.Address, append(transfers, t))
+}
+
+// total returns the amount of denom transferred to address since the given time.
+func (l *ledger) total(address, denom string, since time.Time) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transfers, err := l.get(address)
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, t := range transfers {
+		if t.Time.Before(since) {
+			continue
+		}
+		coins, err := sdk.ParseCoinsNormalized(t.Coins)
+		if err != nil {
+			return 0, err
+		}
+		total += coins.AmountOf(denom).Uint64()
+	}
+	return total, nil
+}
+
+// recent returns the last limit transfers of the ledger, the most recent first.
+func (l *ledger) recent(limit int) ([]Transfer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	all, err := l.transfers.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []Transfer
+	for _, t := range all {
+		transfers = append(transfers, t...)
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].Time.After(transfers[j].Time)
+	})
+
+	if len(transfers) > limit {
+		transfers = transfers[:limit]
+	}
+	return transfers, nil
+}
+
+// transfersOf returns the transfers to address, the most recent first.
+func (l *ledger) transfersOf(address string) ([]Transfer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transfers, err := l.get(address)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(transfers)-1; i < j; i, j = i+1, j-1 {
+		transfers[i], transfers[j] = transfers[j], transfers[i]
+	}
+	return transfers, nil
+}
+
+func (l *ledger) get(address string) ([]Transfer, error) {
+	transfers, err := l.transfers.Get(address)
+	if errors.Is(err, cache.ErrorNotFound) {
+		return nil, nil
+	}
+	return transfers, err
+}
@@ -0,0 +1,52 @@
+package cosmosfaucet
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+)
+
+func TestLedger(t *testing.T) {
+	storage, err := cache.NewStorage(filepath.Join(t.TempDir(), "ledger.db"))
+	require.NoError(t, err)
+
+	l := newLedger(storage)
+	l.retention = time.Hour
+	now := time.Now()
+
+	total, err := l.total("cosmos1alice", "token", now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, total)
+
+	require.NoError(t, l.record(Transfer{Address: "cosmos1alice", Coins: "10token,5stake", TxHash: "A", Time: now.Add(-2 * time.Hour)}))
+	require.NoError(t, l.record(Transfer{Address: "cosmos1alice", Coins: "20token", TxHash: "B", Time: now.Add(-time.Minute)}))
+	require.NoError(t, l.record(Transfer{Address: "cosmos1bob", Coins: "30token", TxHash: "C", Time: now}))
+
+	total, err = l.total("cosmos1alice", "token", now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), total)
+
+	total, err = l.total("cosmos1bob", "token", now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), total)
+
+	// the ledger is persisted and transfers older than the retention are pruned.
+	transfers, err := newLedger(storage).get("cosmos1alice")
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, "B", transfers[0].TxHash)
+
+	transfers, err = l.recent(10)
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, "C", transfers[0].TxHash)
+	require.Equal(t, "B", transfers[1].TxHash)
+
+	transfers, err = l.recent(1)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+}
@@ -0,0 +1,155 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace is the namespace of the Prometheus metrics of the faucet.
+const metricsNamespace = "faucet"
+
+// balanceTimeout is the time to wait for the balance of the faucet account when metrics are scraped.
+const balanceTimeout = 3 * time.Second
+
+// Reasons of the denied transfer requests.
+const (
+	denyRateLimited     = "rate_limited"
+	denyChallengeFailed = "challenge_failed"
+	denyInvalidRequest  = "invalid_request"
+	denyPaused          = "paused"
+	denyBlocked         = "blocked"
+	denyMaxAmount       = "max_amount"
+)
+
+// metrics are the Prometheus metrics of the faucet, they're shared by the copies of the faucet.
+// The methods of a nil metrics are no-ops.
+type metrics struct {
+	registry *prometheus.Registry
+
+	requests          prometheus.Counter
+	denied            *prometheus.CounterVec
+	transferErrors    prometheus.Counter
+	transferredAmount *prometheus.CounterVec
+	balance           *balanceCollector
+}
+
+func newMetrics(balance *balanceCollector) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of transfer requests received.",
+		}),
+		denied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "denied_requests_total",
+			Help:      "Number of transfer requests denied, by reason.",
+		}, []string{"reason"}),
+		transferErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transfer_errors_total",
+			Help:      "Number of transfers that failed.",
+		}),
+		transferredAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "transferred_amount_total",
+			Help:      "Amount of tokens transferred, by denom.",
+		}, []string{"denom"}),
+		balance: balance,
+	}
+
+	m.registry.MustRegister(m.requests, m.denied, m.transferErrors, m.transferredAmount, balance)
+	return m
+}
+
+func (m *metrics) request() {
+	if m == nil {
+		return
+	}
+	m.requests.Inc()
+}
+
+func (m *metrics) deny(reason string) {
+	if m == nil {
+		return
+	}
+	m.denied.WithLabelValues(reason).Inc()
+}
+
+func (m *metrics) transferError() {
+	if m == nil {
+		return
+	}
+	m.transferErrors.Inc()
+}
+
+func (m *metrics) transferred(coins sdk.Coins) {
+	if m == nil {
+		return
+	}
+	for _, c := range coins {
+		amount, _ := c.Amount.ToDec().Float64()
+		m.transferredAmount.WithLabelValues(c.Denom).Add(amount)
+	}
+}
+
+// handler returns the handler of the /metrics endpoint.
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// balanceCollector collects the remaining balances of the faucet accounts from the API of the chain
+// when the metrics are scraped.
+type balanceCollector struct {
+	pool *accountPool
+	desc *prometheus.Desc
+
+	// balance queries the balance of an address, it's overridden in tests.
+	balance func(ctx context.Context, address string) (sdk.Coins, error)
+}
+
+func newBalanceCollector(pool *accountPool, balance func(ctx context.Context, address string) (sdk.Coins, error)) *balanceCollector {
+	return &balanceCollector{
+		pool:    pool,
+		balance: balance,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "balance"),
+			"Remaining balance of the faucet accounts, by account and denom.",
+			[]string{"account", "denom"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *balanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+// The balance of an account is left out when it can't be queried, e.g. while the chain is starting.
+func (c *balanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), balanceTimeout)
+	defer cancel()
+
+	for _, account := range c.pool.names {
+		address, err := c.pool.address(ctx, account)
+		if err != nil {
+			continue
+		}
+		balance, err := c.balance(ctx, address)
+		if err != nil {
+			continue
+		}
+		for _, coin := range balance {
+			amount, _ := coin.Amount.ToDec().Float64()
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, amount, account, coin.Denom)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultBalanceCheckInterval is the interval between the checks of the balances of the faucet accounts.
+const DefaultBalanceCheckInterval = 30 * time.Second
+
+// EventType is the type of an event of the faucet.
+type EventType string
+
+const (
+	// EventLowBalance is emitted when the balance of an account of the faucet drops below
+	// the low balance threshold.
+	EventLowBalance EventType = "low_balance"
+
+	// EventRefilled is emitted when an account of the faucet is refilled from the treasury account.
+	EventRefilled EventType = "refilled"
+
+	// EventRefillFailed is emitted when an account of the faucet can't be refilled.
+	EventRefillFailed EventType = "refill_failed"
+)
+
+// Event is an event of the faucet about one of its accounts.
+type Event struct {
+	Type EventType
+
+	// Account and Address are the name and the address of the account.
+	Account string
+	Address string
+
+	// Balance is the balance of the account when the event is emitted.
+	Balance sdk.Coins
+
+	// Coins are the coins sent by a refill.
+	Coins sdk.Coins
+
+	// Err is the reason of a failed refill.
+	Err error
+}
+
+// accountPool holds the accounts of the faucet. Transfers are sent from the accounts in turn,
+// skipping the accounts with a low balance while other accounts have enough tokens.
+type accountPool struct {
+	names []string
+
+	// resolve returns the address of an account, it's overridden in tests.
+	resolve func(ctx context.Context, name string) (string, error)
+
+	mu        sync.Mutex
+	next      int
+	low       map[string]bool
+	addresses map[string]string
+}
+
+func newAccountPool(names []string, resolve func(ctx context.Context, name string) (string, error)) *accountPool {
+	return &accountPool{
+		names:     names,
+		resolve:   resolve,
+		low:       make(map[string]bool),
+		addresses: make(map[string]string),
+	}
+}
+
+// pick returns the account that sends the next transfer.
+func (p *accountPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.names)
+	for i := 0; i < n; i++ {
+		name := p.names[(p.next+i)%n]
+		if !p.low[name] {
+			p.next = (p.next + i + 1) % n
+			return name
+		}
+	}
+
+	// all the accounts are low, they're still used in turn.
+	name := p.names[p.next]
+	p.next = (p.next + 1) % n
+	return name
+}
+
+// setLow sets whether the balance of account is low and returns the previous value.
+func (p *accountPool) setLow(account string, low bool) (wasLow bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	wasLow = p.low[account]
+	p.low[account] = low
+	return wasLow
+}
+
+// address returns the address of account, addresses are resolved once.
+func (p *accountPool) address(ctx context.Context, account string) (string, error) {
+	p.mu.Lock()
+	address, ok := p.addresses[account]
+	p.mu.Unlock()
+	if ok {
+		return address, nil
+	}
+
+	address, err := p.resolve(ctx, account)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.addresses[account] = address
+	p.mu.Unlock()
+
+	return address, nil
+}
+
+// balanceMonitor checks the balances of the accounts of the pool. It emits an event when the
+// balance of an account drops below the threshold and refills the account from the treasury
+// account when a refill is configured.
+type balanceMonitor struct {
+	pool      *accountPool
+	threshold sdk.Coins
+	interval  time.Duration
+
+	// treasury is the account that refills the accounts of the pool with refill coins,
+	// accounts are not refilled when it's empty.
+	treasury string
+	refill   sdk.Coins
+
+	events func(Event)
+
+	// balance and send query the balance of an address and send coins, they're overridden in tests.
+	balance func(ctx context.Context, address string) (sdk.Coins, error)
+	send    func(ctx context.Context, fromAccount, toAddress string, coins sdk.Coins) error
+}
+
+// run checks the balances at each interval until ctx is canceled.
+func (m *balanceMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check checks the balance of each account of the pool.
+// Accounts whose balance can't be queried, e.g. while the chain is starting, are skipped.
+func (m *balanceMonitor) check(ctx context.Context) {
+	for _, account := range m.pool.names {
+		address, err := m.pool.address(ctx, account)
+		if err != nil {
+			continue
+		}
+		balance, err := m.balance(ctx, address)
+		if err != nil {
+			continue
+		}
+
+		low := lowCoins(balance, m.threshold)
+		wasLow := m.pool.setLow(account, !low.Empty())
+		if low.Empty() {
+			continue
+		}
+
+		if !wasLow {
+			m.emit(Event{Type: EventLowBalance, Account: account, Address: address, Balance: balance})
+		}
+
+		if m.treasury == "" {
+			continue
+		}
+
+		// only the denoms below the threshold are refilled.
+		var coins sdk.Coins
+		for _, c := range m.refill {
+			if low.AmountOf(c.Denom).IsPositive() {
+				coins = coins.Add(c)
+			}
+		}
+		if coins.Empty() {
+			continue
+		}
+
+		if err := m.send(ctx, m.treasury, address, coins); err != nil {
+			m.emit(Event{Type: EventRefillFailed, Account: account, Address: address, Balance: balance, Coins: coins, Err: err})
+			continue
+		}
+
+		m.pool.setLow(account, false)
+		m.emit(Event{Type: EventRefilled, Account: account, Address: address, Balance: balance, Coins: coins})
+	}
+}
+
+func (m *balanceMonitor) emit(e Event) {
+	if m.events != nil {
+		m.events(e)
+	}
+}
+
+// lowCoins returns the coins of threshold whose amount in balance is lower.
+func lowCoins(balance, threshold sdk.Coins) sdk.Coins {
+	var low sdk.Coins
+	for _, c := range threshold {
+		if balance.AmountOf(c.Denom).LT(c.Amount) {
+			low = low.Add(c)
+		}
+	}
+	return low
+}
+
+// QueryBalance queries the balance of address with the API of the chain at apiAddress.
+func QueryBalance(ctx context.Context, apiAddress, address string) (sdk.Coins, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/cosmos/bank/v1beta1/balances/%s", apiAddress, url.PathEscape(address)),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("querying the balance: %s", resp.Status)
+	}
+
+	var out struct {
+		Balances sdk.Coins `json:"balances"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Balances, nil
+}
@@ -0,0 +1,110 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(names ...string) *accountPool {
+	return newAccountPool(names, func(_ context.Context, name string) (string, error) {
+		return "cosmos1" + name, nil
+	})
+}
+
+func TestAccountPoolPick(t *testing.T) {
+	p := newTestPool("a", "b", "c")
+
+	// the accounts are used in turn.
+	var picked []string
+	for i := 0; i < 4; i++ {
+		picked = append(picked, p.pick())
+	}
+	require.Equal(t, []string{"a", "b", "c", "a"}, picked)
+
+	// the accounts with a low balance are skipped.
+	require.False(t, p.setLow("c", true))
+	picked = nil
+	for i := 0; i < 3; i++ {
+		picked = append(picked, p.pick())
+	}
+	require.Equal(t, []string{"b", "a", "b"}, picked)
+
+	// they're still used when all the accounts are low.
+	p.setLow("a", true)
+	p.setLow("b", true)
+	picked = nil
+	for i := 0; i < 3; i++ {
+		picked = append(picked, p.pick())
+	}
+	require.Equal(t, []string{"c", "a", "b"}, picked)
+}
+
+func TestBalanceMonitor(t *testing.T) {
+	balances := map[string]sdk.Coins{
+		"cosmos1a": sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("token", 100)),
+		"cosmos1b": sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("token", 5)),
+	}
+	var (
+		events  []Event
+		sendErr error
+	)
+	m := &balanceMonitor{
+		pool:      newTestPool("a", "b"),
+		threshold: sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 10)),
+		events:    func(e Event) { events = append(events, e) },
+		balance: func(_ context.Context, address string) (sdk.Coins, error) {
+			return balances[address], nil
+		},
+	}
+
+	// a low balance is reported once.
+	m.check(context.Background())
+	m.check(context.Background())
+	require.Equal(t, []Event{{
+		Type:    EventLowBalance,
+		Account: "b",
+		Address: "cosmos1b",
+		Balance: balances["cosmos1b"],
+	}}, events)
+	require.Equal(t, "a", m.pool.pick())
+	require.Equal(t, "a", m.pool.pick())
+
+	// only the low denoms are refilled from the treasury.
+	m.treasury = "treasury"
+	m.refill = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000), sdk.NewInt64Coin("token", 1000))
+	m.send = func(_ context.Context, fromAccount, toAddress string, coins sdk.Coins) error {
+		require.Equal(t, "treasury", fromAccount)
+		require.Equal(t, "cosmos1b", toAddress)
+		if sendErr != nil {
+			return sendErr
+		}
+		balances[toAddress] = balances[toAddress].Add(coins...)
+		return nil
+	}
+
+	sendErr = errors.New("insufficient funds")
+	events = nil
+	m.check(context.Background())
+	require.Len(t, events, 1)
+	require.Equal(t, EventRefillFailed, events[0].Type)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token", 1000)), events[0].Coins)
+	require.Equal(t, sendErr, events[0].Err)
+
+	sendErr = nil
+	events = nil
+	m.check(context.Background())
+	require.Len(t, events, 1)
+	require.Equal(t, EventRefilled, events[0].Type)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token", 1000)), events[0].Coins)
+	require.Equal(t, sdk.NewInt64Coin("token", 1005), balances["cosmos1b"][1])
+
+	// the refilled account is used again.
+	events = nil
+	m.check(context.Background())
+	require.Empty(t, events)
+	require.Equal(t, []string{"b", "a"}, []string{m.pool.pick(), m.pool.pick()})
+}
@@ -0,0 +1,151 @@
+package cosmosfaucet
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a client has sent too many requests.
+type ErrRateLimited struct {
+	// RetryAfter is the time until the client can send a new request.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e ErrRateLimited) Error() string {
+	return fmt.Sprintf("too many requests, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// ipLimiter limits the number of requests accepted from an IP and from its subnet
+// (/24 for IPv4, /64 for IPv6) within a sliding window.
+// A zero limit disables the limit.
+type ipLimiter struct {
+	ipRequests     int
+	subnetRequests int
+	window         time.Duration
+	trustProxy     bool
+
+	mu        sync.Mutex
+	requests  map[string][]time.Time
+	lastSweep time.Time
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+func newIPLimiter() *ipLimiter {
+	return &ipLimiter{
+		requests: make(map[string][]time.Time),
+		now:      time.Now,
+	}
+}
+
+// enabled returns true when at least one limit is set.
+func (l *ipLimiter) enabled() bool {
+	return l.window > 0 && (l.ipRequests > 0 || l.subnetRequests > 0)
+}
+
+// allow records a request from ip and returns ErrRateLimited when the ip or its subnet
+// has reached its limit. Denied requests are not recorded.
+func (l *ipLimiter) allow(ip net.IP) error {
+	if !l.enabled() {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	keys := []struct {
+		key   string
+		limit int
+	}{
+		{"ip/" + ip.String(), l.ipRequests},
+		{"subnet/" + subnet(ip), l.subnetRequests},
+	}
+
+	for _, k := range keys {
+		if k.limit == 0 {
+			continue
+		}
+		times := l.recent(k.key, now)
+		if len(times) >= k.limit {
+			return ErrRateLimited{RetryAfter: times[0].Add(l.window).Sub(now)}
+		}
+	}
+
+	for _, k := range keys {
+		if k.limit != 0 {
+			l.requests[k.key] = append(l.requests[k.key], now)
+		}
+	}
+	return nil
+}
+
+// recent drops the requests of key older than the window and returns the remaining ones.
+func (l *ipLimiter) recent(key string, now time.Time) []time.Time {
+	times := l.requests[key]
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= l.window {
+		i++
+	}
+	times = times[i:]
+
+	if len(times) == 0 {
+		delete(l.requests, key)
+	} else {
+		l.requests[key] = times
+	}
+	return times
+}
+
+// sweep drops the keys of clients that didn't send requests during the last window
+// so that the memory used by the limiter doesn't grow with the number of clients.
+func (l *ipLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key := range l.requests {
+		l.recent(key, now)
+	}
+	l.lastSweep = now
+}
+
+// clientIP returns the IP of the client that sent r.
+// The last address of the X-Forwarded-For header is used when the faucet runs
+// behind a trusted reverse proxy: it's the address appended by the proxy, while the
+// addresses before it are sent by the client and can't be trusted.
+func (l *ipLimiter) clientIP(r *http.Request) (net.IP, error) {
+	if l.trustProxy {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := net.ParseIP(strings.TrimSpace(addrs[len(addrs)-1])); ip != nil {
+				return ip, nil
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid remote address %q", r.RemoteAddr)
+	}
+	return ip, nil
+}
+
+// subnet returns the /24 subnet of an IPv4 address or the /64 subnet of an IPv6 address.
+func subnet(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}).String()
+}
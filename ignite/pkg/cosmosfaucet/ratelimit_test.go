@@ -0,0 +1,80 @@
+package cosmosfaucet
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newIPLimiter()
+	l.ipRequests, l.subnetRequests, l.window = 2, 3, time.Minute
+	l.now = func() time.Time { return now }
+
+	var (
+		alice = net.ParseIP("10.0.0.1")
+		bob   = net.ParseIP("10.0.0.2")
+		carol = net.ParseIP("10.0.1.1")
+	)
+
+	require.NoError(t, l.allow(alice))
+	require.NoError(t, l.allow(alice))
+	require.Equal(t, ErrRateLimited{RetryAfter: time.Minute}, l.allow(alice))
+
+	// the subnet of alice allows 1 more request.
+	require.NoError(t, l.allow(bob))
+	require.Error(t, l.allow(bob))
+
+	// another subnet isn't limited.
+	require.NoError(t, l.allow(carol))
+
+	now = now.Add(30 * time.Second)
+	require.Equal(t, ErrRateLimited{RetryAfter: 30 * time.Second}, l.allow(alice))
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, l.allow(alice))
+
+	// old requests are dropped.
+	now = now.Add(time.Hour)
+	require.NoError(t, l.allow(carol))
+	require.Len(t, l.requests, 2)
+}
+
+func TestIPLimiterDisabled(t *testing.T) {
+	l := newIPLimiter()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, l.allow(net.ParseIP("10.0.0.1")))
+	}
+	require.Empty(t, l.requests)
+}
+
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.9, 198.51.100.7")
+
+	l := newIPLimiter()
+	ip, err := l.clientIP(r)
+	require.NoError(t, err)
+	require.Equal(t, "192.0.2.1", ip.String())
+
+	// the address appended by the proxy is used, the client can't spoof it.
+	l.trustProxy = true
+	ip, err = l.clientIP(r)
+	require.NoError(t, err)
+	require.Equal(t, "198.51.100.7", ip.String())
+
+	r.Header.Add("X-Forwarded-For", "198.51.100.8")
+	ip, err = l.clientIP(r)
+	require.NoError(t, err)
+	require.Equal(t, "198.51.100.8", ip.String())
+}
+
+func TestSubnet(t *testing.T) {
+	require.Equal(t, "10.0.0.0/24", subnet(net.ParseIP("10.0.0.42")))
+	require.Equal(t, "2001:db8:1:2::/64", subnet(net.ParseIP("2001:db8:1:2:3:4:5:6")))
+}
@@ -2,28 +2,77 @@ package cosmosfaucet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
 )
 
 // transferMutex is a mutex used for keeping transfer requests in a queue so checking account balance and sending tokens is atomic
+// when the faucet transfers with the binary of the chain.
 var transferMutex = &sync.Mutex{}
 
-// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress.
+var (
+	// ErrInvalidAddress is returned when a transfer is requested for an address that is not
+	// an account address of the chain.
+	ErrInvalidAddress = errors.New("invalid address")
+
+	// errFaucetStopped is returned when a transfer is requested from a faucet that is stopped.
+	errFaucetStopped = errors.New("faucet is stopped")
+)
+
+// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress
+// within the refresh window.
+// The amount is computed from the ledger when the faucet has one, otherwise from the tx events of the chain.
 func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, denom string) (totalAmount uint64, err error) {
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	if f.ledger == nil {
+		return f.chainTransferredAmount(ctx, toAccountAddress, denom)
+	}
+
+	totalAmount, err = f.ledger.total(toAccountAddress, denom, time.Now().Add(-f.limitRefreshWindow))
+	if err != nil || !f.reconcile {
+		return totalAmount, err
+	}
+
+	chainAmount, err := f.chainTransferredAmount(ctx, toAccountAddress, denom)
+	if err != nil {
+		return 0, err
+	}
+	if chainAmount > totalAmount {
+		return chainAmount, nil
+	}
+	return totalAmount, nil
+}
+
+// chainTransferredAmount returns the total transferred amount from the faucet accounts to toAccountAddress
+// within the refresh window by querying the tx events of the chain.
+func (f Faucet) chainTransferredAmount(ctx context.Context, toAccountAddress, denom string) (totalAmount uint64, err error) {
+	for _, account := range f.pool.names {
+		amount, err := f.accountTransferredAmount(ctx, account, toAccountAddress, denom)
+		if err != nil {
+			return 0, err
+		}
+		totalAmount += amount
+	}
+	return totalAmount, nil
+}
+
+// accountTransferredAmount returns the total transferred amount from account to toAccountAddress
+// within the refresh window by querying the tx events of the chain.
+func (f Faucet) accountTransferredAmount(ctx context.Context, account, toAccountAddress, denom string) (totalAmount uint64, err error) {
+	fromAddress, err := f.pool.address(ctx, account)
 	if err != nil {
 		return 0, err
 	}
 
 	events, err := f.runner.QueryTxEvents(ctx,
-		chaincmdrunner.NewEventSelector("message", "sender", fromAccount.Address),
+		chaincmdrunner.NewEventSelector("message", "sender", fromAddress),
 		chaincmdrunner.NewEventSelector("transfer", "recipient", toAccountAddress))
 	if err != nil {
 		return 0, err
@@ -51,51 +100,164 @@ func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, de
 	return totalAmount, nil
 }
 
-// Transfer transfer amount of tokens from the faucet account to toAccountAddress.
-func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) error {
-	transferMutex.Lock()
-	defer transferMutex.Unlock()
+// ErrMaxAmount is returned when a transfer exceeds the max amount of a denom that an address can receive.
+type ErrMaxAmount struct {
+	Denom string
+	Max   uint64
 
-	var coinsStr []string
+	// Reached is true when the address has already received the max amount.
+	Reached bool
+}
 
-	// check for each coin, the max transferred amount hasn't been reached
+// Error implements error.
+func (e ErrMaxAmount) Error() string {
+	if e.Reached {
+		return fmt.Sprintf("account has reached to the max. allowed amount (%d) for %q denom", e.Max, e.Denom)
+	}
+	return fmt.Sprintf(
+		`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
+		e.Denom,
+		e.Max,
+	)
+}
+
+// checkMaxAmounts returns an error when transferring coins to toAccountAddress exceeds the max amounts
+// of the faucet. reserved are the coins of the transfers to toAccountAddress in progress.
+func (f Faucet) checkMaxAmounts(ctx context.Context, toAccountAddress string, coins, reserved sdk.Coins) error {
 	for _, c := range coins {
+		max := f.settings.maxAmount(c.Denom)
+		if max == 0 {
+			continue
+		}
+
 		totalSent, err := f.TotalTransferredAmount(ctx, toAccountAddress, c.Denom)
 		if err != nil {
 			return err
 		}
+		totalSent += reserved.AmountOf(c.Denom).Uint64()
 
-		if f.coinsMax[c.Denom] != 0 {
-			if totalSent >= f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					"account has reached to the max. allowed amount (%d) for %q denom",
-					f.coinsMax[c.Denom],
-					c.Denom,
-				)
-			}
+		if totalSent >= max {
+			return ErrMaxAmount{Denom: c.Denom, Max: max, Reached: true}
+		}
 
-			if (totalSent + c.Amount.Uint64()) > f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
-					c.Denom,
-					f.coinsMax[c.Denom],
-				)
-			}
+		if (totalSent + c.Amount.Uint64()) > max {
+			return ErrMaxAmount{Denom: c.Denom, Max: max}
 		}
+	}
+	return nil
+}
 
-		coinsStr = append(coinsStr, c.String())
+// Transfer transfer amount of tokens from the faucet account to toAccountAddress
+// and returns the hash of the transfer tx.
+// With a native signer, concurrent transfers are sent together in a single tx.
+func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) (txHash string, err error) {
+	if err := f.settings.check(toAccountAddress); err != nil {
+		return "", err
 	}
 
-	// perform transfer for all coins
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	account := f.pool.pick()
+
+	// an invalid address in a batch would make the whole tx fail, so it's rejected before
+	// being queued.
+	if err := f.validateAddress(ctx, account, toAccountAddress); err != nil {
+		return "", err
+	}
+
+	if f.batchers == nil {
+		return f.transferWithCLI(ctx, account, toAccountAddress, coins)
+	}
+
+	coins = append(sdk.Coins{}, coins...).Sort()
+	if err := coins.Validate(); err != nil {
+		return "", err
+	}
+
+	err = f.reservations.reserve(toAccountAddress, coins, func(reserved sdk.Coins) error {
+		return f.checkMaxAmounts(ctx, toAccountAddress, coins, reserved)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return f.batchers[account].transfer(ctx, toAccountAddress, coins)
+}
+
+// validateAddress checks that address is a bech32 account address with the prefix of the address of account.
+func (f *Faucet) validateAddress(ctx context.Context, account, address string) error {
+	prefix, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return fmt.Errorf("%w %q: %s", ErrInvalidAddress, address, err)
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return fmt.Errorf("%w %q: %s", ErrInvalidAddress, address, err)
+	}
+
+	fromAddress, err := f.pool.address(ctx, account)
 	if err != nil {
 		return err
 	}
-	txHash, err := f.runner.BankSend(ctx, fromAccount.Address, toAccountAddress, strings.Join(coinsStr, ","))
+	fromPrefix, _, err := bech32.DecodeAndConvert(fromAddress)
 	if err != nil {
 		return err
 	}
+	if prefix != fromPrefix {
+		return fmt.Errorf("%w %q: the address prefix must be %q", ErrInvalidAddress, address, fromPrefix)
+	}
+	return nil
+}
+
+// transferWithCLI transfers coins from account to toAccountAddress with the binary of the chain,
+// one transfer at a time.
+func (f *Faucet) transferWithCLI(ctx context.Context, account, toAccountAddress string, coins sdk.Coins) (txHash string, err error) {
+	transferMutex.Lock()
+	defer transferMutex.Unlock()
+
+	// check for each coin, the max transferred amount hasn't been reached
+	if err := f.checkMaxAmounts(ctx, toAccountAddress, coins, nil); err != nil {
+		return "", err
+	}
+
+	var coinsStr []string
+	for _, c := range coins {
+		coinsStr = append(coinsStr, c.String())
+	}
+
+	// perform transfer for all coins
+	fromAddress, err := f.pool.address(ctx, account)
+	if err != nil {
+		return "", err
+	}
+	txHash, err = f.runner.BankSend(ctx, fromAddress, toAccountAddress, strings.Join(coinsStr, ","))
+	if err != nil {
+		return "", err
+	}
+
+	// record the transfer as soon as it is broadcast, so it counts in the limits even
+	// when waiting for its confirmation fails.
+	if f.ledger != nil {
+		if err := f.ledger.record(Transfer{
+			Address: toAccountAddress,
+			Coins:   strings.Join(coinsStr, ","),
+			TxHash:  txHash,
+			Time:    time.Now(),
+		}); err != nil {
+			return txHash, err
+		}
+	}
 
 	// wait for the send tx to be confirmed
+	return txHash, f.runner.WaitTx(ctx, txHash, time.Second, 30)
+}
+
+// refill sends coins from fromAccount to toAddress with the binary of the chain.
+func (f Faucet) refill(ctx context.Context, fromAccount, toAddress string, coins sdk.Coins) error {
+	from, err := f.runner.ShowAccount(ctx, fromAccount)
+	if err != nil {
+		return err
+	}
+	txHash, err := f.runner.BankSend(ctx, from.Address, toAddress, coins.String())
+	if err != nil {
+		return err
+	}
 	return f.runner.WaitTx(ctx, txHash, time.Second, 30)
 }
@@ -33,13 +33,16 @@ const (
 type (
 	// Genesis represents a more readable version of the stargate genesis file
 	Genesis struct {
-		Accounts   []string
-		StakeDenom string
+		Accounts      []string
+		StakeDenom    string
+		InitialHeight int64
 	}
 	// ChainGenesis represents the stargate genesis file
 	ChainGenesis struct {
-		ChainID  string `json:"chain_id"`
-		AppState struct {
+		ChainID string `json:"chain_id"`
+		// InitialHeight is encoded as a string or a number depending on the SDK version.
+		InitialHeight json.Number `json:"initial_height,omitempty"`
+		AppState      struct {
 			Auth struct {
 				Accounts []struct {
 					Address string `json:"address"`
@@ -132,6 +135,40 @@ func UpdateGenesis(genesisPath string, options ...GenesisField) error {
 	return os.WriteFile(genesisPath, genesisBytes, 0644)
 }
 
+// UpdateGenesisMap decodes the genesis file at genesisPath into a map, applies update to it
+// and writes the result back. Numbers are kept as json.Number so that large amounts don't lose precision.
+func UpdateGenesisMap(genesisPath string, update func(genesis map[string]interface{}) error) error {
+	genesis, err := ParseGenesisMapFromPath(genesisPath)
+	if err != nil {
+		return err
+	}
+	if err := update(genesis); err != nil {
+		return err
+	}
+
+	genesisBytes, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(genesisPath, genesisBytes, 0644)
+}
+
+// ParseGenesisMapFromPath decodes the genesis file at genesisPath into a map, numbers are decoded as json.Number.
+func ParseGenesisMapFromPath(genesisPath string) (map[string]interface{}, error) {
+	genesisFile, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot open genesis file")
+	}
+
+	var genesis map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(genesisFile))
+	d.UseNumber()
+	if err := d.Decode(&genesis); err != nil {
+		return nil, errors.New("cannot unmarshal the genesis file: " + err.Error())
+	}
+	return genesis, nil
+}
+
 // ParseGenesisFromPath parse ChainGenesis object from a genesis file
 func ParseGenesisFromPath(genesisPath string) (Genesis, error) {
 	genesisFile, err := os.ReadFile(genesisPath)
@@ -156,6 +193,11 @@ func ParseGenesis(genesisFile []byte) (Genesis, error) {
 		return Genesis{}, errors.New("cannot unmarshal the genesis file: " + err.Error())
 	}
 	genesis := Genesis{StakeDenom: chainGenesis.AppState.Staking.Params.BondDenom}
+	if chainGenesis.InitialHeight != "" {
+		if genesis.InitialHeight, err = chainGenesis.InitialHeight.Int64(); err != nil {
+			return Genesis{}, errors.Wrap(err, "invalid initial height")
+		}
+	}
 	for _, acc := range chainGenesis.AppState.Auth.Accounts {
 		genesis.Accounts = append(genesis.Accounts, acc.Address)
 	}
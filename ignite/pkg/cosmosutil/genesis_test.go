@@ -1,6 +1,7 @@
 package cosmosutil_test
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -53,13 +54,13 @@ func TestChainGenesis_HasAccount(t *testing.T) {
 }
 
 func TestParseChainGenesis(t *testing.T) {
-	genesis1 := cosmosutil.ChainGenesis{ChainID: "earth-1"}
+	genesis1 := cosmosutil.ChainGenesis{ChainID: "earth-1", InitialHeight: "1"}
 	genesis1.AppState.Auth.Accounts = []struct {
 		Address string `json:"address"`
 	}{{Address: "cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"}}
 	genesis1.AppState.Staking.Params.BondDenom = "stake"
 
-	genesis2 := cosmosutil.ChainGenesis{ChainID: "earth-1"}
+	genesis2 := cosmosutil.ChainGenesis{ChainID: "earth-1", InitialHeight: "1"}
 	genesis2.AppState.Auth.Accounts = []struct {
 		Address string `json:"address"`
 	}{{Address: "cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"}}
@@ -112,15 +113,17 @@ func TestParseGenesis(t *testing.T) {
 			name:        "parse genesis file 1",
 			genesisPath: "testdata/genesis1.json",
 			want: cosmosutil.Genesis{
-				Accounts:   []string{"cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"},
-				StakeDenom: "stake",
+				Accounts:      []string{"cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"},
+				StakeDenom:    "stake",
+				InitialHeight: 1,
 			},
 		}, {
 			name:        "parse genesis file 2",
 			genesisPath: "testdata/genesis2.json",
 			want: cosmosutil.Genesis{
-				Accounts:   []string{"cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"},
-				StakeDenom: "stake",
+				Accounts:      []string{"cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"},
+				StakeDenom:    "stake",
+				InitialHeight: 1,
 			},
 		}, {
 			name:        "parse not found file",
@@ -141,6 +144,7 @@ func TestParseGenesis(t *testing.T) {
 			require.NoError(t, err)
 			require.ElementsMatch(t, tt.want.Accounts, got.Accounts)
 			require.Equal(t, tt.want.StakeDenom, got.StakeDenom)
+			require.Equal(t, tt.want.InitialHeight, got.InitialHeight)
 		})
 	}
 }
@@ -156,15 +160,17 @@ func TestParseGenesisFromPath(t *testing.T) {
 			name:        "parse genesis file 1",
 			genesisPath: "testdata/genesis1.json",
 			want: cosmosutil.Genesis{
-				Accounts:   []string{"cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"},
-				StakeDenom: "stake",
+				Accounts:      []string{"cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"},
+				StakeDenom:    "stake",
+				InitialHeight: 1,
 			},
 		}, {
 			name:        "parse genesis file 2",
 			genesisPath: "testdata/genesis2.json",
 			want: cosmosutil.Genesis{
-				Accounts:   []string{"cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"},
-				StakeDenom: "stake",
+				Accounts:      []string{"cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"},
+				StakeDenom:    "stake",
+				InitialHeight: 1,
 			},
 		}, {
 			name:        "parse not found file",
@@ -182,10 +188,89 @@ func TestParseGenesisFromPath(t *testing.T) {
 			require.NoError(t, err)
 			require.ElementsMatch(t, tt.want.Accounts, got.Accounts)
 			require.Equal(t, tt.want.StakeDenom, got.StakeDenom)
+			require.Equal(t, tt.want.InitialHeight, got.InitialHeight)
 		})
 	}
 }
 
+func TestParseGenesisInitialHeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		genesis string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "string height",
+			genesis: `{"initial_height":"42"}`,
+			want:    42,
+		},
+		{
+			name:    "numeric height",
+			genesis: `{"initial_height":7}`,
+			want:    7,
+		},
+		{
+			name:    "no height",
+			genesis: `{"chain_id":"mars"}`,
+			want:    0,
+		},
+		{
+			name:    "invalid height",
+			genesis: `{"initial_height":"seven"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cosmosutil.ParseGenesis([]byte(tt.genesis))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.InitialHeight)
+		})
+	}
+}
+
+func TestUpdateGenesisMap(t *testing.T) {
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	err := os.WriteFile(genesisPath, []byte(`{
+  "chain_id": "earth-1",
+  "app_state": {"bank": {"supply": [{"denom": "stake", "amount": "1"}], "max": 18446744073709551615}}
+}`), 0644)
+	require.NoError(t, err)
+
+	err = cosmosutil.UpdateGenesisMap(genesisPath, func(genesis map[string]interface{}) error {
+		genesis["chain_id"] = "earth-2"
+		return nil
+	})
+	require.NoError(t, err)
+
+	genesis, err := cosmosutil.ParseGenesisMapFromPath(genesisPath)
+	require.NoError(t, err)
+	require.Equal(t, "earth-2", genesis["chain_id"])
+
+	// large numbers keep their precision.
+	bank := genesis["app_state"].(map[string]interface{})["bank"].(map[string]interface{})
+	require.Equal(t, json.Number("18446744073709551615"), bank["max"])
+
+	// the genesis is not written when the update fails.
+	err = cosmosutil.UpdateGenesisMap(genesisPath, func(genesis map[string]interface{}) error {
+		genesis["chain_id"] = "earth-3"
+		return errors.New("oops")
+	})
+	require.Error(t, err)
+
+	genesis, err = cosmosutil.ParseGenesisMapFromPath(genesisPath)
+	require.NoError(t, err)
+	require.Equal(t, "earth-2", genesis["chain_id"])
+
+	_, err = cosmosutil.ParseGenesisMapFromPath(filepath.Join(t.TempDir(), "genesis.json"))
+	require.Error(t, err)
+}
+
 func TestUpdateGenesis(t *testing.T) {
 	genesisSample := `
 {
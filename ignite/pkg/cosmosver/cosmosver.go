@@ -37,6 +37,8 @@ var (
 	StargateFortyVersion          = newVersion("0.40.0", Stargate)
 	StargateFortyFourVersion      = newVersion("0.44.0-alpha", Stargate)
 	StargateFortyFiveThreeVersion = newVersion("0.45.3", Stargate)
+	StargateFortySixVersion       = newVersion("0.46.0-alpha", Stargate)
+	StargateFortySevenVersion     = newVersion("0.47.0-alpha", Stargate)
 )
 
 var (
@@ -0,0 +1,123 @@
+// Package cyclonedx generates CycloneDX software bills of materials.
+package cyclonedx
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const (
+	// BOMFormat is the format identifier of CycloneDX documents.
+	BOMFormat = "CycloneDX"
+
+	// SpecVersion is the version of the CycloneDX specification of generated documents.
+	SpecVersion = "1.4"
+
+	// ComponentTypeApplication is the component type of the main module.
+	ComponentTypeApplication = "application"
+
+	// ComponentTypeLibrary is the component type of module dependencies.
+	ComponentTypeLibrary = "library"
+
+	// ScopeRequired is the scope of module dependencies, direct and indirect
+	// dependencies are both compiled into the binary.
+	ScopeRequired = "required"
+)
+
+// BOM is a CycloneDX software bill of materials.
+type BOM struct {
+	BOMFormat   string      `json:"bomFormat"`
+	SpecVersion string      `json:"specVersion"`
+	Version     int         `json:"version"`
+	Metadata    Metadata    `json:"metadata"`
+	Components  []Component `json:"components"`
+}
+
+// Metadata describes the subject of the BOM.
+type Metadata struct {
+	Timestamp string    `json:"timestamp"`
+	Component Component `json:"component"`
+}
+
+// Component is a software component included in the BOM.
+type Component struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl"`
+	Scope   string `json:"scope,omitempty"`
+}
+
+// FromGoMod creates a BOM from the go.mod file of a module with the given version.
+// Replaced requirements are listed with their replacements, requirements replaced
+// by a local directory are listed with their module path and no version. The
+// timestamp is used as the creation time of the BOM so that it can be reproduced.
+func FromGoMod(f *modfile.File, version string, timestamp time.Time) BOM {
+	main := newComponent(ComponentTypeApplication, module.Version{
+		Path:    f.Module.Mod.Path,
+		Version: version,
+	})
+
+	// replaces are keyed by path@version, and by path for the replaces of all versions.
+	replaces := make(map[string]module.Version)
+	for _, r := range f.Replace {
+		replaces[r.Old.String()] = r.New
+	}
+
+	components := make([]Component, 0, len(f.Require))
+	for _, r := range f.Require {
+		mod := r.Mod
+		replacement, ok := replaces[mod.String()]
+		if !ok {
+			replacement, ok = replaces[mod.Path]
+		}
+		if ok {
+			if replacement.Version == "" {
+				// local directory replacements have no module version.
+				mod = module.Version{Path: mod.Path}
+			} else {
+				mod = replacement
+			}
+		}
+
+		c := newComponent(ComponentTypeLibrary, mod)
+		c.Scope = ScopeRequired
+		components = append(components, c)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].BOMRef < components[j].BOMRef
+	})
+
+	return BOM{
+		BOMFormat:   BOMFormat,
+		SpecVersion: SpecVersion,
+		Version:     1,
+		Metadata: Metadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Component: main,
+		},
+		Components: components,
+	}
+}
+
+// newComponent creates a component for a Go module.
+func newComponent(componentType string, mod module.Version) Component {
+	purl := fmt.Sprintf("pkg:golang/%s", mod.Path)
+	if mod.Version != "" {
+		purl = fmt.Sprintf("%s@%s", purl, mod.Version)
+	}
+
+	return Component{
+		Type:    componentType,
+		BOMRef:  purl,
+		Name:    mod.Path,
+		Version: mod.Version,
+		PURL:    purl,
+	}
+}
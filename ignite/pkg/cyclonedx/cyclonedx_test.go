@@ -0,0 +1,87 @@
+package cyclonedx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+)
+
+func TestFromGoMod(t *testing.T) {
+	gomod := `module github.com/foo/mars
+
+go 1.18
+
+require (
+	github.com/cosmos/cosmos-sdk v0.45.4
+	github.com/gogo/protobuf v1.3.3 // indirect
+)
+
+replace github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+`
+
+	f, err := modfile.Parse("go.mod", []byte(gomod), nil)
+	require.NoError(t, err)
+
+	bom := FromGoMod(f, "1.0.0", time.Unix(1650000000, 0))
+
+	require.Equal(t, "CycloneDX", bom.BOMFormat)
+	require.Equal(t, "2022-04-15T05:20:00Z", bom.Metadata.Timestamp)
+	require.Equal(t, "pkg:golang/github.com/foo/mars@1.0.0", bom.Metadata.Component.PURL)
+	require.Equal(t, []Component{
+		{
+			Type:    ComponentTypeLibrary,
+			BOMRef:  "pkg:golang/github.com/cosmos/cosmos-sdk@v0.45.4",
+			Name:    "github.com/cosmos/cosmos-sdk",
+			Version: "v0.45.4",
+			PURL:    "pkg:golang/github.com/cosmos/cosmos-sdk@v0.45.4",
+			Scope:   ScopeRequired,
+		},
+		{
+			Type:    ComponentTypeLibrary,
+			BOMRef:  "pkg:golang/github.com/regen-network/protobuf@v1.3.3-alpha.regen.1",
+			Name:    "github.com/regen-network/protobuf",
+			Version: "v1.3.3-alpha.regen.1",
+			PURL:    "pkg:golang/github.com/regen-network/protobuf@v1.3.3-alpha.regen.1",
+			Scope:   ScopeRequired,
+		},
+	}, bom.Components)
+}
+
+func TestFromGoModReplaces(t *testing.T) {
+	gomod := `module github.com/foo/mars
+
+go 1.18
+
+require (
+	github.com/foo/a v1.0.0
+	github.com/foo/b v1.0.0
+	github.com/foo/c v1.0.0
+	github.com/foo/d v1.0.0
+)
+
+replace (
+	github.com/foo/a v0.9.0 => github.com/bar/a v0.9.1
+	github.com/foo/b v1.0.0 => github.com/bar/b v1.0.1
+	github.com/foo/c => github.com/bar/c v1.1.0
+	github.com/foo/d => ../d
+)
+`
+
+	f, err := modfile.Parse("go.mod", []byte(gomod), nil)
+	require.NoError(t, err)
+
+	var purls []string
+	for _, c := range FromGoMod(f, "1.0.0", time.Unix(1650000000, 0)).Components {
+		purls = append(purls, c.PURL)
+	}
+	require.Equal(t, []string{
+		// the replace of another version is not applied.
+		"pkg:golang/github.com/bar/b@v1.0.1",
+		"pkg:golang/github.com/bar/c@v1.1.0",
+		"pkg:golang/github.com/foo/a@v1.0.0",
+		// the local replace is listed without a version.
+		"pkg:golang/github.com/foo/d",
+	}, purls)
+}
@@ -1,6 +1,7 @@
 package gocmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,6 +29,9 @@ const (
 
 	// CommandModVerify represents go mod "verify" command.
 	CommandModVerify = "verify"
+
+	// CommandEnv represents go "env" command.
+	CommandEnv = "env"
 )
 
 const (
@@ -35,11 +39,13 @@ const (
 	FlagModValueReadOnly = "readonly"
 	FlagLdflags          = "-ldflags"
 	FlagOut              = "-o"
+	FlagTrimpath         = "-trimpath"
 )
 
 const (
-	EnvGOOS   = "GOOS"
-	EnvGOARCH = "GOARCH"
+	EnvGOOS      = "GOOS"
+	EnvGOARCH    = "GOARCH"
+	EnvGOVERSION = "GOVERSION"
 )
 
 // Name returns the name of Go binary to use.
@@ -61,6 +67,18 @@ func ModVerify(ctx context.Context, path string, options ...exec.Option) error {
 	return exec.Exec(ctx, []string{Name(), CommandMod, CommandModVerify}, append(options, exec.StepOption(step.Workdir(path)))...)
 }
 
+// Env returns the value of the Go environment variable name.
+func Env(ctx context.Context, name string, options ...exec.Option) (string, error) {
+	var b bytes.Buffer
+	err := exec.Exec(ctx, []string{Name(), CommandEnv, name}, append(options, exec.StepOption(step.Stdout(&b)))...)
+	return strings.TrimSpace(b.String()), err
+}
+
+// Version returns the version of the Go toolchain, e.g. go1.18.2.
+func Version(ctx context.Context) (string, error) {
+	return Env(ctx, EnvGOVERSION)
+}
+
 // BuildPath runs go install on cmd folder with options.
 func BuildPath(ctx context.Context, output, binary, path string, flags []string, options ...exec.Option) error {
 	binaryOutput, err := binaryPath(output, binary)
@@ -0,0 +1,142 @@
+package localfs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// globAnyDirs is the glob segment that matches any number of path segments.
+const globAnyDirs = "**"
+
+// MatchGlob reports whether the slash separated path matches pattern.
+// Besides the path.Match syntax, a "**" segment in pattern matches zero or more
+// path segments, e.g. "x/**/*.go" matches "x/mars/keeper/msg_server.go".
+func MatchGlob(pattern, name string) (bool, error) {
+	return matchSegments(splitGlob(pattern), splitGlob(name))
+}
+
+// MatchGlobOrParent reports whether the slash separated path or one of its
+// parent directories matches pattern, so a pattern matching a directory matches
+// every file inside it.
+func MatchGlobOrParent(pattern, name string) (bool, error) {
+	segments := splitGlob(name)
+	patternSegments := splitGlob(pattern)
+
+	for i := len(segments); i > 0; i-- {
+		ok, err := matchSegments(patternSegments, segments[:i])
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// CheckGlob returns path.ErrBadPattern when pattern is malformed.
+func CheckGlob(pattern string) error {
+	for _, segment := range splitGlob(pattern) {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GlobRoot returns the longest leading directory of pattern that contains no
+// glob meta characters. It returns "." when the first segment is already a glob.
+func GlobRoot(pattern string) string {
+	var root []string
+	for _, segment := range splitGlob(pattern) {
+		if segment == globAnyDirs || strings.ContainsAny(segment, `*?[\`) {
+			break
+		}
+		root = append(root, segment)
+	}
+
+	if len(root) == 0 {
+		return "."
+	}
+	return path.Join(root...)
+}
+
+// GlobFiles returns the sorted, slash separated paths relative to root of the
+// files that match one of the include patterns and none of the exclude
+// patterns, with the same rules as the watcher: a pattern matching a directory
+// matches every file inside it and excluded directories are not walked.
+func GlobFiles(root string, include, exclude []string) ([]string, error) {
+	found := make(map[string]bool)
+	for _, pattern := range include {
+		dir := filepath.Join(root, filepath.FromSlash(GlobRoot(pattern)))
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+
+			if matchAny(exclude, rel) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() && matchAny(include, rel) {
+				found[rel] = true
+			}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	files := make([]string, 0, len(found))
+	for file := range found {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func splitGlob(name string) []string {
+	name = strings.Trim(path.Clean("/"+name), "/")
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, "/")
+}
+
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == globAnyDirs {
+			// try to match the rest of the pattern with every suffix of name.
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchSegments(pattern[1:], name[i:])
+				if err != nil || ok {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(name) == 0 {
+			return false, nil
+		}
+
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false, err
+		}
+
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0, nil
+}
@@ -0,0 +1,81 @@
+package localfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"x/**/*.go", "x/mars/keeper/msg_server.go", true},
+		{"x/**/*.go", "x/genesis.go", true},
+		{"x/**/*.go", "x/mars/keeper/README.md", false},
+		{"**/*.pb.go", "x/mars/types/query.pb.go", true},
+		{"**", "anything/at/all", true},
+		{"app/*.go", "app/app.go", true},
+		{"app/*.go", "app/params/encoding.go", false},
+		{"vendor", "vendor", true},
+		{"vendor", "vendor/github.com/foo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			got, err := MatchGlob(tt.pattern, tt.name)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := MatchGlob("x/[", "x/a")
+	require.Error(t, err)
+}
+
+func TestCheckGlob(t *testing.T) {
+	require.NoError(t, CheckGlob("x/**/*.go"))
+	require.Error(t, CheckGlob("x/[/*.go"))
+}
+
+func TestMatchGlobOrParent(t *testing.T) {
+	ok, err := MatchGlobOrParent("vendor", "vendor/github.com/foo/foo.go")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = MatchGlobOrParent("**/testdata", "x/mars/testdata/genesis.json")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = MatchGlobOrParent("vendor", "x/vendor.go")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestGlobRoot(t *testing.T) {
+	require.Equal(t, "x", GlobRoot("x/**/*.go"))
+	require.Equal(t, "app/params", GlobRoot("app/params/*.go"))
+	require.Equal(t, "go.mod", GlobRoot("go.mod"))
+	require.Equal(t, ".", GlobRoot("**/*.go"))
+	require.Equal(t, ".", GlobRoot("*.go"))
+}
+
+func TestGlobFiles(t *testing.T) {
+	dir := setupGlobTests(t, []string{
+		"app/app.go",
+		"x/mars/keeper.go",
+		"x/mars/README.md",
+		"x/mars/types/query.pb.go",
+		"vendor/foo/foo.go",
+		"config.yml",
+	})
+
+	files, err := GlobFiles(dir, []string{"**/*.go"}, []string{"**/*.pb.go", "vendor"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"app/app.go", "x/mars/keeper.go"}, files)
+
+	files, err = GlobFiles(dir, []string{"x", "missing"}, []string{"x/mars/types"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"x/mars/README.md", "x/mars/keeper.go"}, files)
+}
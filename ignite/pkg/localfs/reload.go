@@ -0,0 +1,51 @@
+package localfs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadTrigger returns a channel that receives every time the process gets a
+// SIGHUP signal or a line is read from in, e.g. when Enter is pressed on stdin.
+// It is meant to be used with WatcherManualReload.
+func ReloadTrigger(ctx context.Context, in io.Reader) <-chan struct{} {
+	reload := make(chan struct{})
+
+	trigger := func() {
+		select {
+		case reload <- struct{}{}:
+		case <-ctx.Done():
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-signals:
+				trigger()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if in != nil {
+		go func() {
+			scanner := bufio.NewScanner(in)
+			for scanner.Scan() && ctx.Err() == nil {
+				trigger()
+			}
+		}()
+	}
+
+	return reload
+}
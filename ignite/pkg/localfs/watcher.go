@@ -3,6 +3,7 @@ package localfs
 import (
 	"context"
 	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,12 @@ type watcher struct {
 	ignoreHidden  bool
 	ignoreFolders bool
 	ignoreExts    []string
+	include       []string
+	exclude       []string
+	paths         []string
+	debounce      time.Duration
+	manual        bool
+	reload        <-chan struct{}
 	onChange      func()
 	interval      time.Duration
 	ctx           context.Context
@@ -68,6 +75,41 @@ func WatcherIgnoreExt(exts ...string) WatcherOption {
 	}
 }
 
+// WatcherInclude only watches the files matching one of the glob patterns.
+// Patterns are relative to the workdir and support "**" to match any number of
+// directories, see MatchGlob. The leading directories of the patterns are watched
+// in addition to the paths given to Watch, whose files are always watched.
+func WatcherInclude(patterns ...string) WatcherOption {
+	return func(w *watcher) {
+		w.include = patterns
+	}
+}
+
+// WatcherExclude ignores the files and directories matching one of the glob patterns.
+// Patterns are relative to the workdir, excluded directories are not traversed.
+func WatcherExclude(patterns ...string) WatcherOption {
+	return func(w *watcher) {
+		w.exclude = patterns
+	}
+}
+
+// WatcherDebounce delays the change hook until no change happened for d, so a
+// burst of changes executes the hook only once.
+func WatcherDebounce(d time.Duration) WatcherOption {
+	return func(w *watcher) {
+		w.debounce = d
+	}
+}
+
+// WatcherManualReload disables executing the change hook on filesystem changes.
+// The hook is executed every time reload receives instead, see ReloadTrigger.
+func WatcherManualReload(reload <-chan struct{}) WatcherOption {
+	return func(w *watcher) {
+		w.manual = true
+		w.reload = reload
+	}
+}
+
 // Watch starts watching changes on the paths. options are used to configure the
 // behaviour of watch operation.
 func Watch(ctx context.Context, paths []string, options ...WatcherOption) error {
@@ -84,11 +126,27 @@ func Watch(ctx context.Context, paths []string, options ...WatcherOption) error
 		o(w)
 	}
 
+	workdir, err := filepath.Abs(w.workdir)
+	if err != nil {
+		return err
+	}
+	w.workdir = workdir
+
+	for _, path := range paths {
+		w.paths = append(w.paths, w.absPath(path))
+	}
+
 	w.wt.AddFilterHook(func(info os.FileInfo, fullPath string) error {
-		if info.IsDir() && w.ignoreFolders {
+		if w.isExcluded(fullPath) {
 			return wt.ErrSkip
 		}
-		if w.isFileIgnored(fullPath) {
+		if info.IsDir() {
+			if w.ignoreFolders {
+				return wt.ErrSkip
+			}
+			return nil
+		}
+		if !w.isIncluded(fullPath) || w.isFileIgnored(fullPath) {
 			return wt.ErrSkip
 		}
 
@@ -99,7 +157,11 @@ func Watch(ctx context.Context, paths []string, options ...WatcherOption) error
 	w.wt.IgnoreHiddenFiles(w.ignoreHidden)
 
 	// add paths to watch
-	if err := w.addPaths(paths...); err != nil {
+	watchPaths := w.paths
+	for _, pattern := range w.include {
+		watchPaths = append(watchPaths, w.absPath(GlobRoot(pattern)))
+	}
+	if err := w.addPaths(watchPaths...); err != nil {
 		return err
 	}
 
@@ -115,9 +177,23 @@ func Watch(ctx context.Context, paths []string, options ...WatcherOption) error
 
 func (w *watcher) listen() {
 	defer w.done.Done()
+
+	var debounced <-chan time.Time
 	for {
 		select {
 		case <-w.wt.Event:
+			switch {
+			case w.manual:
+				// changes are picked up on the next manual reload.
+			case w.debounce > 0:
+				debounced = time.After(w.debounce)
+			default:
+				w.onChange()
+			}
+		case <-debounced:
+			debounced = nil
+			w.onChange()
+		case <-w.reload:
 			w.onChange()
 		case <-w.wt.Closed:
 			return
@@ -129,15 +205,18 @@ func (w *watcher) listen() {
 
 func (w *watcher) addPaths(paths ...string) error {
 	for _, path := range paths {
-		if !filepath.IsAbs(path) {
-			path = filepath.Join(w.workdir, path)
-		}
+		path = w.absPath(path)
 
 		// Ignoring paths that don't exist
 		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
 			continue
 		}
 
+		// excluded directories are ignored so the watcher doesn't traverse them.
+		if err := w.ignoreExcludedDirs(path); err != nil {
+			return err
+		}
+
 		if err := w.wt.AddRecursive(path); err != nil {
 			return err
 		}
@@ -146,6 +225,80 @@ func (w *watcher) addPaths(paths ...string) error {
 	return nil
 }
 
+func (w *watcher) ignoreExcludedDirs(root string) error {
+	if len(w.exclude) == 0 {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if w.isExcluded(path) {
+			if err := w.wt.Ignore(path); err != nil {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+func (w *watcher) absPath(path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(w.workdir, path)
+	}
+	return filepath.Clean(path)
+}
+
+// relPath returns the slash separated path relative to the workdir, ok is false
+// when the path is outside the workdir.
+func (w *watcher) relPath(path string) (rel string, ok bool) {
+	rel, err := filepath.Rel(w.workdir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func (w *watcher) isExcluded(path string) bool {
+	rel, ok := w.relPath(path)
+	if !ok {
+		return false
+	}
+	return matchAny(w.exclude, rel)
+}
+
+func (w *watcher) isIncluded(path string) bool {
+	if len(w.include) == 0 {
+		return true
+	}
+
+	// files of the paths given to Watch are always included.
+	for _, p := range w.paths {
+		if path == p || strings.HasPrefix(path, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	rel, ok := w.relPath(path)
+	if !ok {
+		return false
+	}
+	return matchAny(w.include, rel)
+}
+
+// matchAny reports whether path or one of its parents matches one of the patterns.
+// Invalid patterns never match, they are expected to be validated by the caller.
+func matchAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := MatchGlobOrParent(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *watcher) isFileIgnored(path string) bool {
 	for _, ext := range w.ignoreExts {
 		if strings.HasSuffix(path, ext) {
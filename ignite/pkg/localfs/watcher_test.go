@@ -0,0 +1,170 @@
+package localfs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testWatchInterval = 20 * time.Millisecond
+
+	// testWatchTimeout is the maximum time to wait for a change.
+	testWatchTimeout = 2 * time.Second
+)
+
+// startWatch watches dir in the background and returns a channel receiving the changes.
+func startWatch(t *testing.T, dir string, paths []string, options ...WatcherOption) <-chan struct{} {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	changes := make(chan struct{}, 16)
+	options = append(options,
+		WatcherWorkdir(dir),
+		WatcherPollingInterval(testWatchInterval),
+		WatcherOnChange(func() {
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}),
+	)
+
+	go func() {
+		defer close(done)
+		require.NoError(t, Watch(ctx, paths, options...))
+	}()
+
+	return changes
+}
+
+// waitWatching touches the watched file name until the watcher reports a change,
+// so that the watcher is known to have listed the files. every attempt waits for wait.
+func waitWatching(t *testing.T, dir, name string, changes <-chan struct{}, wait time.Duration) {
+	t.Helper()
+
+	deadline := time.After(testWatchTimeout)
+	for {
+		touch(t, dir, name)
+		select {
+		case <-changes:
+			return
+		case <-time.After(wait):
+		case <-deadline:
+			t.Fatalf("the watcher did not start in %s", testWatchTimeout)
+		}
+	}
+}
+
+// requireChange waits for a change.
+func requireChange(t *testing.T, changes <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-changes:
+	case <-time.After(testWatchTimeout):
+		t.Fatalf("no change in %s", testWatchTimeout)
+	}
+}
+
+// requireNoChange checks that there is no change within d.
+func requireNoChange(t *testing.T, changes <-chan struct{}, d time.Duration) {
+	t.Helper()
+
+	select {
+	case <-changes:
+		t.Fatal("unexpected change")
+	case <-time.After(d):
+	}
+}
+
+func touch(t *testing.T, dir, name string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(time.Now().String()), 0644))
+}
+
+func TestWatchIncludeExclude(t *testing.T) {
+	dir := setupGlobTests(t, []string{
+		"x/mars/keeper.go",
+		"x/mars/README.md",
+		"x/mars/types/query.pb.go",
+		"vendor/foo/foo.go",
+		"config.yml",
+	})
+
+	changes := startWatch(t, dir, []string{"config.yml"},
+		WatcherInclude("x/**/*.go", "vendor/**"),
+		WatcherExclude("**/*.pb.go", "vendor"),
+	)
+	waitWatching(t, dir, "config.yml", changes, 5*testWatchInterval)
+
+	for _, name := range []string{"x/mars/README.md", "x/mars/types/query.pb.go", "vendor/foo/foo.go"} {
+		touch(t, dir, name)
+	}
+	requireNoChange(t, changes, 5*testWatchInterval)
+
+	touch(t, dir, "x/mars/keeper.go")
+	requireChange(t, changes)
+
+	touch(t, dir, "config.yml")
+	requireChange(t, changes)
+}
+
+func TestWatchDebounce(t *testing.T) {
+	dir := setupGlobTests(t, []string{"x/a.go", "x/b.go"})
+
+	debounce := 20 * testWatchInterval
+	changes := startWatch(t, dir, []string{"x"}, WatcherDebounce(debounce))
+	waitWatching(t, dir, "x/b.go", changes, debounce+5*testWatchInterval)
+
+	var lastTouch time.Time
+	for i := 0; i < 4; i++ {
+		touch(t, dir, "x/a.go")
+		lastTouch = time.Now()
+		time.Sleep(3 * testWatchInterval)
+	}
+
+	// the change is reported once, after the debounce following the last modification.
+	requireChange(t, changes)
+	require.GreaterOrEqual(t, time.Since(lastTouch), debounce)
+	requireNoChange(t, changes, debounce+5*testWatchInterval)
+}
+
+func TestWatchManualReload(t *testing.T) {
+	dir := setupGlobTests(t, []string{"x/a.go"})
+	reload := make(chan struct{})
+
+	changes := startWatch(t, dir, []string{"x"}, WatcherManualReload(reload))
+
+	touch(t, dir, "x/a.go")
+	requireNoChange(t, changes, 5*testWatchInterval)
+
+	reload <- struct{}{}
+	requireChange(t, changes)
+}
+
+func TestReloadTrigger(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reload := ReloadTrigger(ctx, strings.NewReader("\n\n"))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-reload:
+		case <-time.After(time.Second):
+			t.Fatal("reload was not triggered by the input line")
+		}
+	}
+}
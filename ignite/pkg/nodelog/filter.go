@@ -0,0 +1,162 @@
+// Package nodelog captures the logs of Cosmos SDK nodes. It parses the Tendermint
+// JSON and plain log lines, filters them by module and level, highlights panics
+// and errors, and writes them to rotated files.
+package nodelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Level is the severity of a log line.
+type Level int
+
+const (
+	// LevelUnknown is the level of the lines that are not log entries, e.g. panics
+	// or the output of a command.
+	LevelUnknown Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// DefaultModule is the filter key matching the modules without a filter of their own.
+const DefaultModule = "*"
+
+// ParseLevel parses a level name as written by the Tendermint and zerolog loggers,
+// e.g. debug, dbg, info, inf, warn, wrn, error or err.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug", "dbg", "d":
+		return LevelDebug, nil
+	case "info", "inf", "i":
+		return LevelInfo, nil
+	case "warn", "warning", "wrn", "w":
+		return LevelWarn, nil
+	case "error", "err", "e":
+		return LevelError, nil
+	default:
+		return LevelUnknown, fmt.Errorf("unknown log level %q", s)
+	}
+}
+
+// String returns the name of the level as accepted by the --log_level flag of the node.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// short returns the three letters name of the level used in the output.
+func (l Level) short() string {
+	switch l {
+	case LevelDebug:
+		return "DBG"
+	case LevelInfo:
+		return "INF"
+	case LevelWarn:
+		return "WRN"
+	case LevelError:
+		return "ERR"
+	default:
+		return "???"
+	}
+}
+
+// Filter holds the minimum level of the log lines to keep for each module.
+// The zero value keeps every line.
+type Filter struct {
+	modules map[string]Level
+}
+
+// ParseFilter parses a comma separated list of module=level filters, e.g.
+// "x/bank=debug,consensus=error,*=info". The * module sets the level of the modules
+// without a filter of their own and defaults to info. The Tendermint module:level
+// notation is accepted too.
+func ParseFilter(s string) (Filter, error) {
+	f := Filter{modules: make(map[string]Level)}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.LastIndexAny(entry, "=:")
+		if i <= 0 {
+			return Filter{}, fmt.Errorf("invalid log filter %q, expected module=level", entry)
+		}
+
+		module := strings.TrimSpace(entry[:i])
+		level, err := ParseLevel(entry[i+1:])
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid log filter %q: %w", entry, err)
+		}
+		f.modules[module] = level
+	}
+
+	if len(f.modules) == 0 {
+		return Filter{}, nil
+	}
+	if _, ok := f.modules[DefaultModule]; !ok {
+		f.modules[DefaultModule] = LevelInfo
+	}
+
+	return f, nil
+}
+
+// IsZero reports whether the filter keeps every line.
+func (f Filter) IsZero() bool {
+	return len(f.modules) == 0
+}
+
+// Allow reports whether a line of module with level is kept. Lines with an unknown
+// level, like panics, are always kept.
+func (f Filter) Allow(module string, level Level) bool {
+	if f.IsZero() || level == LevelUnknown {
+		return true
+	}
+
+	min, ok := f.modules[module]
+	if !ok {
+		min = f.modules[DefaultModule]
+	}
+	return level >= min
+}
+
+// MinLevel returns the lowest level kept by the filter, to be used as the log level
+// of the node so that it produces every line the filter may keep.
+func (f Filter) MinLevel() Level {
+	if f.IsZero() {
+		return LevelInfo
+	}
+
+	min := LevelError
+	for _, level := range f.modules {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+// String returns the filter in the format accepted by ParseFilter.
+func (f Filter) String() string {
+	entries := make([]string, 0, len(f.modules))
+	for module, level := range f.modules {
+		entries = append(entries, module+"="+level.String())
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
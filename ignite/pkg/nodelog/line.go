@@ -0,0 +1,170 @@
+package nodelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// plainLevelRe matches the level of a line written by the zerolog console writer
+	// of the SDK, e.g. "3:04PM INF executed block height=1 module=state".
+	plainLevelRe = regexp.MustCompile(`(?:^|\s)(DBG|INF|WRN|ERR)\s`)
+
+	// tmLevelRe matches the level of a line written by the Tendermint plain logger,
+	// e.g. "I[2022-06-01|10:00:00.000] executed block module=state height=1".
+	tmLevelRe = regexp.MustCompile(`^([DIEW])\[\d{4}-`)
+
+	// moduleRe matches the module field of a plain line.
+	moduleRe = regexp.MustCompile(`(?:^|\s)module=("[^"]*"|\S+)`)
+
+	// panicRe matches the first line of a Go panic or of a recovered panic logged by
+	// the SDK.
+	panicRe = regexp.MustCompile(`^(panic|fatal error): |\bPANIC\b|\bpanic recovered\b|\brecovered: `)
+
+	// stackRe matches the lines of a Go stack trace.
+	stackRe = regexp.MustCompile(`^(goroutine \d+ \[|\t|created by |\s*\S+\.go:\d+|\[recovered\]|\s+panic: )`)
+)
+
+// messageKeys are the keys holding the message of a JSON line, depending on the
+// logger of the node.
+var messageKeys = []string{"message", "_msg", "msg"}
+
+// ignoredKeys are the keys of a JSON line not rendered as fields.
+var ignoredKeys = map[string]bool{
+	"level":  true,
+	"module": true,
+	"time":   true,
+	"ts":     true,
+}
+
+// Line is a parsed log line.
+type Line struct {
+	// Raw is the line as written by the node.
+	Raw string
+
+	// JSON reports whether the line is a JSON log entry.
+	JSON bool
+
+	// Level is the level of the line, unknown when the line is not a log entry.
+	Level Level
+
+	// Module is the module logging the line, e.g. consensus or x/bank.
+	Module string
+
+	// Message is the message of a JSON line.
+	Message string
+
+	// Fields are the other key=value fields of a JSON line, sorted by key.
+	Fields []string
+
+	// Panic reports whether the line starts a panic.
+	Panic bool
+}
+
+// ParseLine parses a line of node output.
+func ParseLine(raw string) Line {
+	raw = strings.TrimRight(raw, "\r\n")
+	line := Line{Raw: raw}
+
+	if strings.HasPrefix(raw, "{") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &entry); err == nil {
+			line.JSON = true
+			line.parseJSON(entry)
+			line.Panic = panicRe.MatchString(line.Message)
+			return line
+		}
+	}
+
+	if m := plainLevelRe.FindStringSubmatch(raw); m != nil {
+		line.Level, _ = ParseLevel(m[1])
+	} else if m := tmLevelRe.FindStringSubmatch(raw); m != nil {
+		line.Level, _ = ParseLevel(m[1])
+	}
+	if m := moduleRe.FindStringSubmatch(raw); m != nil {
+		line.Module = strings.Trim(m[1], `"`)
+	}
+	line.Panic = panicRe.MatchString(raw)
+
+	return line
+}
+
+func (l *Line) parseJSON(entry map[string]interface{}) {
+	if level, ok := entry["level"].(string); ok {
+		l.Level, _ = ParseLevel(level)
+	}
+	if module, ok := entry["module"].(string); ok {
+		l.Module = module
+	}
+
+	keys := make([]string, 0, len(entry))
+	for key := range entry {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messageKey := ""
+	for _, key := range messageKeys {
+		if message, ok := entry[key].(string); ok {
+			l.Message = message
+			messageKey = key
+			break
+		}
+	}
+
+	for _, key := range keys {
+		if ignoredKeys[key] || key == messageKey {
+			continue
+		}
+		l.Fields = append(l.Fields, key+"="+fieldValue(entry[key]))
+	}
+}
+
+// IsError reports whether the line reports an error, e.g. an ABCI error.
+func (l Line) IsError() bool {
+	return l.Level == LevelError
+}
+
+// IsStack reports whether the line is part of a Go stack trace.
+func (l Line) IsStack() bool {
+	return !l.JSON && stackRe.MatchString(l.Raw)
+}
+
+// String renders the line for humans. JSON lines are rendered like the console
+// writer of the SDK, other lines are returned as written.
+func (l Line) String() string {
+	if !l.JSON {
+		return l.Raw
+	}
+
+	var b strings.Builder
+	b.WriteString(l.Level.short())
+	if l.Module != "" {
+		fmt.Fprintf(&b, " [%s]", l.Module)
+	}
+	if l.Message != "" {
+		b.WriteString(" ")
+		b.WriteString(l.Message)
+	}
+	for _, field := range l.Fields {
+		b.WriteString(" ")
+		b.WriteString(field)
+	}
+	return b.String()
+}
+
+func fieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if strings.ContainsAny(v, " \t\n\"") {
+			return fmt.Sprintf("%q", v)
+		}
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,108 @@
+package nodelog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("x/bank=debug, consensus:error")
+	require.NoError(t, err)
+	require.Equal(t, "*=info,consensus=error,x/bank=debug", f.String())
+	require.Equal(t, LevelDebug, f.MinLevel())
+
+	require.True(t, f.Allow("x/bank", LevelDebug))
+	require.False(t, f.Allow("consensus", LevelInfo))
+	require.True(t, f.Allow("consensus", LevelError))
+	require.False(t, f.Allow("p2p", LevelDebug))
+	require.True(t, f.Allow("p2p", LevelInfo))
+	require.True(t, f.Allow("p2p", LevelUnknown))
+
+	f, err = ParseFilter("*=error")
+	require.NoError(t, err)
+	require.Equal(t, LevelError, f.MinLevel())
+	require.False(t, f.Allow("x/bank", LevelWarn))
+
+	f, err = ParseFilter("")
+	require.NoError(t, err)
+	require.True(t, f.IsZero())
+	require.True(t, f.Allow("x/bank", LevelDebug))
+
+	_, err = ParseFilter("x/bank")
+	require.EqualError(t, err, `invalid log filter "x/bank", expected module=level`)
+
+	_, err = ParseFilter("x/bank=loud")
+	require.EqualError(t, err, `invalid log filter "x/bank=loud": unknown log level "loud"`)
+}
+
+func TestParseLine(t *testing.T) {
+	line := ParseLine(`{"level":"info","module":"state","height":3,"num_txs":0,"time":"2022-06-01T10:00:00Z","message":"executed block"}` + "\n")
+	require.True(t, line.JSON)
+	require.Equal(t, LevelInfo, line.Level)
+	require.Equal(t, "state", line.Module)
+	require.Equal(t, "INF [state] executed block height=3 num_txs=0", line.String())
+
+	line = ParseLine(`{"level":"error","module":"consensus","err":"wrong Block.Header.AppHash","_msg":"failed to apply block"}`)
+	require.True(t, line.IsError())
+	require.Equal(t, `ERR [consensus] failed to apply block err="wrong Block.Header.AppHash"`, line.String())
+
+	line = ParseLine("3:04PM DBG flushing block module=x/bank height=2")
+	require.False(t, line.JSON)
+	require.Equal(t, LevelDebug, line.Level)
+	require.Equal(t, "x/bank", line.Module)
+
+	line = ParseLine("E[2022-06-01|10:00:00.000] failed to apply block module=consensus")
+	require.Equal(t, LevelError, line.Level)
+	require.Equal(t, "consensus", line.Module)
+
+	line = ParseLine("panic: runtime error: index out of range [1] with length 1")
+	require.True(t, line.Panic)
+	require.Equal(t, LevelUnknown, line.Level)
+
+	require.True(t, ParseLine("\t/home/user/mars/x/mars/keeper/msg_server.go:42 +0x1d").IsStack())
+	require.True(t, ParseLine("goroutine 1 [running]:").IsStack())
+}
+
+func TestWriter(t *testing.T) {
+	filter, err := ParseFilter("x/bank=debug,*=error")
+	require.NoError(t, err)
+
+	var out, file bytes.Buffer
+	w := NewWriter(&out, WithFilter(filter), WithFile(&file), WithPrefix("[mars] "), WithoutHighlight())
+
+	input := []string{
+		`{"level":"debug","module":"x/bank","message":"sent coins"}`,
+		`{"level":"info","module":"consensus","message":"finalizing commit"}`,
+		`{"level":"error","module":"consensus","message":"failed"}`,
+		`panic: keeper is nil`,
+		``,
+		`goroutine 1 [running]:`,
+		"\t/home/user/mars/x/mars/keeper/keeper.go:10 +0x1d",
+		`{"level":"info","module":"p2p","message":"dialing"}`,
+	}
+	data := strings.Join(input, "\n") + "\n"
+
+	// write in chunks to check that lines split across writes are handled.
+	_, err = w.Write([]byte(data[:20]))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(data[20:]))
+	require.NoError(t, err)
+
+	require.Equal(t, data, file.String())
+	require.Equal(t, strings.Join([]string{
+		"[mars] DBG [x/bank] sent coins",
+		"[mars] ERR [consensus] failed",
+		"[mars] panic: keeper is nil",
+		"[mars] ",
+		"[mars] goroutine 1 [running]:",
+		"[mars] \t/home/user/mars/x/mars/keeper/keeper.go:10 +0x1d",
+	}, "\n")+"\n", out.String())
+
+	_, err = w.Write([]byte("exit status 2"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.True(t, strings.HasSuffix(out.String(), "[mars] exit status 2\n"))
+}
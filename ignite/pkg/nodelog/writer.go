@@ -0,0 +1,148 @@
+package nodelog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/gookit/color"
+)
+
+var (
+	panicColor = color.New(color.FgRed, color.OpBold).Render
+	errorColor = color.Red.Render
+	warnColor  = color.Yellow.Render
+)
+
+// Writer is an io.Writer capturing the output of a node line by line. Every line is
+// written as is to the file, and the lines kept by the filter are rendered for
+// humans with panics and errors highlighted to the output.
+type Writer struct {
+	mu        sync.Mutex
+	out       io.Writer
+	file      io.Writer
+	filter    Filter
+	prefix    string
+	highlight bool
+	buf       []byte
+
+	// inPanic reports whether the lines written belong to a panic stack trace.
+	inPanic bool
+}
+
+// Option configures the writer.
+type Option func(*Writer)
+
+// WithFilter sets the filter of the lines written to the output.
+func WithFilter(filter Filter) Option {
+	return func(w *Writer) {
+		w.filter = filter
+	}
+}
+
+// WithFile sets the writer receiving every line as written by the node.
+func WithFile(file io.Writer) Option {
+	return func(w *Writer) {
+		w.file = file
+	}
+}
+
+// WithPrefix sets the prefix of the lines written to the output.
+func WithPrefix(prefix string) Option {
+	return func(w *Writer) {
+		w.prefix = prefix
+	}
+}
+
+// WithoutHighlight disables the colors of panics and errors in the output.
+func WithoutHighlight() Option {
+	return func(w *Writer) {
+		w.highlight = false
+	}
+}
+
+// NewWriter creates a new writer rendering the node logs to out.
+func NewWriter(out io.Writer, options ...Option) *Writer {
+	w := &Writer{
+		out:       out,
+		file:      io.Discard,
+		highlight: true,
+	}
+
+	for _, apply := range options {
+		apply(w)
+	}
+
+	return w
+}
+
+// Write implements io.Writer. Incomplete lines are buffered until their end is written.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		raw := string(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+
+		if err := w.writeLine(raw); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the buffered incomplete line, if any.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	raw := string(w.buf)
+	w.buf = nil
+	return w.writeLine(raw + "\n")
+}
+
+func (w *Writer) writeLine(raw string) error {
+	if _, err := io.WriteString(w.file, raw); err != nil {
+		return err
+	}
+
+	line := ParseLine(raw)
+
+	switch {
+	case line.Panic:
+		w.inPanic = true
+	case w.inPanic && !line.IsStack() && line.Raw != "":
+		w.inPanic = false
+	}
+
+	if !w.inPanic && !w.filter.Allow(line.Module, line.Level) {
+		return nil
+	}
+
+	text := line.String()
+	if w.highlight {
+		switch {
+		case w.inPanic:
+			text = panicColor(text)
+		case line.IsError():
+			text = errorColor(text)
+		case line.Level == LevelWarn:
+			text = warnColor(text)
+		}
+	}
+
+	_, err := io.WriteString(w.out, w.prefix+text+"\n")
+	return err
+}
@@ -0,0 +1,142 @@
+package protoanalysis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// Field is a field of a proto message.
+type Field struct {
+	// Name of the field as written in the proto file.
+	Name string
+
+	// Type of the field as written in the proto file, e.g. string, Post or
+	// cosmos.base.v1beta1.Coin. For maps, Type is the type of the values.
+	Type string
+
+	// Repeated indicates if the field is a list.
+	Repeated bool
+
+	// Map indicates if the field is a map.
+	Map bool
+}
+
+// JSONName returns the lowerCamelCase name of the field used by the proto JSON encoding.
+func (f Field) JSONName() string {
+	var (
+		b     strings.Builder
+		upper bool
+	)
+	for _, r := range f.Name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = []rune(strings.ToUpper(string(r)))[0]
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// MessageFields maps the fully qualified names of proto messages to their fields,
+// e.g. cosmos.bank.v1beta1.GenesisState. Nested messages are named after their
+// parents, e.g. mars.A.B.
+type MessageFields map[string][]Field
+
+// ParseMessageFields parses the proto files found at path and returns the fields
+// of their messages.
+func ParseMessageFields(ctx context.Context, path string) (MessageFields, error) {
+	parsed, err := parse(ctx, path, protoFilePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(MessageFields)
+	for _, p := range parsed {
+		for _, message := range p.messages() {
+			fields[messageFullName(p.name, message)] = messageFields(message)
+		}
+	}
+
+	return fields, nil
+}
+
+// Resolve returns the fully qualified name of the message type typ used by a field
+// of the message named from, following the proto scoping rules. It returns false
+// when the message type is not known, e.g. for scalar types.
+func (m MessageFields) Resolve(from, typ string) (string, bool) {
+	if strings.HasPrefix(typ, ".") {
+		typ = strings.TrimPrefix(typ, ".")
+		_, ok := m[typ]
+		return typ, ok
+	}
+
+	scope := from
+	for scope != "" {
+		name := scope + "." + typ
+		if _, ok := m[name]; ok {
+			return name, true
+		}
+
+		i := strings.LastIndex(scope, ".")
+		if i < 0 {
+			break
+		}
+		scope = scope[:i]
+	}
+
+	_, ok := m[typ]
+	return typ, ok
+}
+
+func messageFullName(pkgName string, message *proto.Message) string {
+	name := message.Name
+	for parent := message.Parent; parent != nil; {
+		parentMessage, ok := parent.(*proto.Message)
+		if !ok {
+			break
+		}
+		name = parentMessage.Name + "." + name
+		parent = parentMessage.Parent
+	}
+
+	if pkgName == "" {
+		return name
+	}
+	return pkgName + "." + name
+}
+
+func messageFields(message *proto.Message) (fields []Field) {
+	for _, elem := range message.Elements {
+		switch elem := elem.(type) {
+		case *proto.NormalField:
+			fields = append(fields, Field{
+				Name:     elem.Name,
+				Type:     elem.Type,
+				Repeated: elem.Repeated,
+			})
+		case *proto.MapField:
+			fields = append(fields, Field{
+				Name: elem.Name,
+				Type: elem.Type,
+				Map:  true,
+			})
+		case *proto.Oneof:
+			for _, oneofElem := range elem.Elements {
+				if field, ok := oneofElem.(*proto.OneOfField); ok {
+					fields = append(fields, Field{
+						Name: field.Name,
+						Type: field.Type,
+					})
+				}
+			}
+		}
+	}
+
+	return fields
+}
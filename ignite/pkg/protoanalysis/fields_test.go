@@ -0,0 +1,38 @@
+package protoanalysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessageFields(t *testing.T) {
+	fields, err := ParseMessageFields(context.Background(), "testdata/liquidity")
+	require.NoError(t, err)
+
+	require.Equal(t, []Field{
+		{Name: "params", Type: "Params"},
+		{Name: "pool_records", Type: "PoolRecord", Repeated: true},
+	}, fields["tendermint.liquidity.GenesisState"])
+
+	name, ok := fields.Resolve("tendermint.liquidity.GenesisState", "PoolRecord")
+	require.True(t, ok)
+	require.Equal(t, "tendermint.liquidity.PoolRecord", name)
+
+	_, ok = fields.Resolve("tendermint.liquidity.GenesisState", "string")
+	require.False(t, ok)
+
+	require.Equal(t, "poolRecords", Field{Name: "pool_records"}.JSONName())
+	require.Equal(t, "postList", Field{Name: "postList"}.JSONName())
+}
+
+func TestParseNestedMessageFields(t *testing.T) {
+	fields, err := ParseMessageFields(context.Background(), "testdata/nested_messages")
+	require.NoError(t, err)
+	require.Contains(t, fields, "nested_messages.A.B.C")
+
+	name, ok := fields.Resolve("nested_messages.A", "B.C")
+	require.True(t, ok)
+	require.Equal(t, "nested_messages.A.B.C", name)
+}
@@ -140,6 +140,11 @@ func (c *Chain) TryRetrieve(ctx context.Context) (sdk.Coins, error) {
 	return c.r.balance(ctx, c.rpcAddress, c.accountName, c.addressPrefix)
 }
 
+// Balance returns the balance of the relayer account on the chain.
+func (c *Chain) Balance(ctx context.Context) (sdk.Coins, error) {
+	return c.r.balance(ctx, c.rpcAddress, c.accountName, c.addressPrefix)
+}
+
 // channelOptions represents options for configuring the IBC channel between two chains
 type channelOptions struct {
 	sourcePort    string
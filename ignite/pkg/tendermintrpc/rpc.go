@@ -104,6 +104,18 @@ func (c Client) GetGenesis(ctx context.Context) (Genesis, error) {
 // NodeInfo holds node info.
 type NodeInfo struct {
 	Network string
+
+	// LatestBlockHeight is the height of the latest block committed by the node.
+	LatestBlockHeight int64 `json:"-"`
+
+	// CatchingUp is true while the node is syncing blocks from its peers.
+	CatchingUp bool `json:"-"`
+}
+
+// syncInfo holds the sync info of a status response.
+type syncInfo struct {
+	LatestBlockHeight string `json:"latest_block_height"`
+	CatchingUp        bool   `json:"catching_up"`
 }
 
 // Status retrieves node Status.
@@ -132,6 +144,7 @@ func (c Client) Status(ctx context.Context) (NodeInfo, error) {
 	var out struct {
 		Result struct {
 			NodeInfo NodeInfo `json:"node_info"`
+			SyncInfo syncInfo `json:"sync_info"`
 		} `json:"result"`
 	}
 
@@ -140,12 +153,14 @@ func (c Client) Status(ctx context.Context) (NodeInfo, error) {
 	}
 
 	info = out.Result.NodeInfo
+	sync := out.Result.SyncInfo
 
 	// some Stargate versions have a different response payload.
 	if info.Network == "" {
 		var out struct {
 			Result struct {
 				NodeInfo NodeInfo `json:"NodeInfo"`
+				SyncInfo syncInfo `json:"SyncInfo"`
 			} `json:"result"`
 		}
 
@@ -154,7 +169,17 @@ func (c Client) Status(ctx context.Context) (NodeInfo, error) {
 		}
 
 		info = out.Result.NodeInfo
+		sync = out.Result.SyncInfo
+	}
+
+	if sync.LatestBlockHeight != "" {
+		height, err := strconv.ParseInt(sync.LatestBlockHeight, 10, 64)
+		if err != nil {
+			return NodeInfo{}, err
+		}
+		info.LatestBlockHeight = height
 	}
+	info.CatchingUp = sync.CatchingUp
 
 	return info, nil
 }
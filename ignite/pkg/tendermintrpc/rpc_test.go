@@ -0,0 +1,22 @@
+package tendermintrpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, endpointStatus, r.URL.Path)
+		w.Write([]byte(`{"result":{"node_info":{"network":"mars"},"sync_info":{"latest_block_height":"42","catching_up":true}}}`))
+	}))
+	defer ts.Close()
+
+	info, err := New(ts.URL).Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, NodeInfo{Network: "mars", LatestBlockHeight: 42, CatchingUp: true}, info)
+}
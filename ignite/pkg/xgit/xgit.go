@@ -1,9 +1,13 @@
 package xgit
 
 import (
+	"context"
+	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 func AreChangesCommitted(appPath string) (bool, error) {
@@ -31,3 +35,69 @@ func AreChangesCommitted(appPath string) (bool, error) {
 	}
 	return ws.IsClean(), nil
 }
+
+// HeadCommit returns the hash and the committer time of the HEAD commit of the
+// repository at appPath. Empty values are returned when appPath is not a git repository.
+func HeadCommit(appPath string) (hash string, when time.Time, err error) {
+	appPath, err = filepath.Abs(appPath)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	repository, err := git.PlainOpen(appPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			return "", time.Time{}, nil
+		}
+		return "", time.Time{}, err
+	}
+
+	head, err := repository.Head()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return commit.Hash.String(), commit.Committer.When, nil
+}
+
+// CloneAt clones the repository at srcPath into dstPath and checks out ref.
+// ref can be a branch, a tag or a commit hash of the source repository.
+func CloneAt(ctx context.Context, srcPath, dstPath, ref string) (hash string, err error) {
+	srcPath, err = filepath.Abs(srcPath)
+	if err != nil {
+		return "", err
+	}
+
+	repository, err := git.PlainCloneContext(ctx, dstPath, false, &git.CloneOptions{
+		URL:  srcPath,
+		Tags: git.AllTags,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// branches of the source repository are only available as remote branches in the clone.
+	revision, err := repository.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		revision, err = repository.ResolveRevision(plumbing.Revision(fmt.Sprintf("%s/%s", git.DefaultRemoteName, ref)))
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve git ref %q: %w", ref, err)
+	}
+
+	w, err := repository.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if err := w.Checkout(&git.CheckoutOptions{Hash: *revision}); err != nil {
+		return "", err
+	}
+
+	return revision.String(), nil
+}
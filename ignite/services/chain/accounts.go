@@ -0,0 +1,235 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
+)
+
+// addVestingAccount 將帳戶作為鎖倉帳戶添加到創世，鎖倉時間相對於 now 計算。
+func (c *Chain) addVestingAccount(
+	ctx context.Context,
+	commands chaincmdrunner.Runner,
+	account chainconfig.Account,
+	address string,
+	now time.Time,
+) error {
+	start, end, err := account.Vesting.Times(now)
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	var options []chaincmd.VestingAccountOption
+	if account.Vesting.VestingType() == chainconfig.VestingContinuous {
+		options = append(options, chaincmd.VestingWithStartTime(start))
+	}
+
+	if err := commands.AddVestingAccount(
+		ctx,
+		address,
+		strings.Join(account.Coins, ","),
+		strings.Join(account.Vesting.Coins, ","),
+		end,
+		options...,
+	); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		c.stdLog().out,
+		"🔒 帳戶 %q 的 %s 按 %s 計劃鎖定到 %s\n",
+		account.Name,
+		strings.Join(account.Vesting.Coins, ","),
+		account.Vesting.VestingType(),
+		time.Unix(end, 0).UTC().Format(time.RFC3339),
+	)
+	return nil
+}
+
+// addModuleAccounts 將 config.yml 中模塊帳戶的代幣存入模塊地址，並加入主節點創世的代幣總供應量。
+// 模塊地址使用 addresses 中已有帳戶地址的前綴，並以帳戶名稱加入 addresses。
+// distribution 模塊啟動時檢查其餘額與社區池和未領取的獎勵是否相等，因此存入的代幣同時加入社區池。
+func (c *Chain) addModuleAccounts(conf chainconfig.Config, addresses map[string]string) error {
+	var modules []chainconfig.Account
+	for _, account := range conf.Accounts {
+		if account.Module != "" {
+			modules = append(modules, account)
+		}
+	}
+	if len(modules) == 0 {
+		return nil
+	}
+
+	prefix, err := addressesPrefix(addresses)
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	var (
+		balances      = make(map[string]sdk.Coins)
+		communityPool sdk.Coins
+	)
+	for _, account := range modules {
+		address, err := moduleAddress(account.Module, prefix)
+		if err != nil {
+			return err
+		}
+		coins, err := sdk.ParseCoinsNormalized(strings.Join(account.Coins, ","))
+		if err != nil {
+			return &CannotBuildAppError{err}
+		}
+
+		addresses[account.Name] = address
+		balances[address] = balances[address].Add(coins...)
+		if account.Module == distrtypes.ModuleName {
+			communityPool = communityPool.Add(coins...)
+		}
+
+		fmt.Fprintf(
+			c.stdLog().out,
+			"🏦 模塊帳戶 %q (%s) 的地址 %q 存入 %s\n",
+			account.Name,
+			account.Module,
+			address,
+			coins,
+		)
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+	return addGenesisBalances(genesisPath, balances, communityPool)
+}
+
+// addressesPrefix 返回 addresses 中帳戶地址的 bech32 前綴。
+func addressesPrefix(addresses map[string]string) (string, error) {
+	names := make([]string, 0, len(addresses))
+	for name := range addresses {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "", errors.New("模塊帳戶需要至少一個普通帳戶來確定地址前綴")
+	}
+	sort.Strings(names)
+
+	return cosmosutil.GetAddressPrefix(addresses[names[0]])
+}
+
+// moduleAddress 返回模塊名為 module 的模塊帳戶使用 prefix 編碼的地址。
+func moduleAddress(module, prefix string) (string, error) {
+	return bech32.ConvertAndEncode(prefix, authtypes.NewModuleAddress(module))
+}
+
+// genesisBalance 是 bank 模塊創世中一個地址的餘額。
+type genesisBalance struct {
+	Address string    `json:"address"`
+	Coins   sdk.Coins `json:"coins"`
+}
+
+// addGenesisBalances 將 balances 中的代幣加入位於 genesisPath 的創世的 bank 餘額和總供應量，
+// 並將 communityPool 加入 distribution 模塊的社區池。
+func addGenesisBalances(genesisPath string, balances map[string]sdk.Coins, communityPool sdk.Coins) error {
+	return cosmosutil.UpdateGenesisMap(genesisPath, func(genesis map[string]interface{}) error {
+		appState, ok := genesis["app_state"].(map[string]interface{})
+		if !ok {
+			return errors.New("創世中沒有 app_state")
+		}
+		bank, ok := appState["bank"].(map[string]interface{})
+		if !ok {
+			return errors.New("創世中沒有 bank 模塊的狀態")
+		}
+
+		var state struct {
+			Balances []genesisBalance `json:"balances"`
+			Supply   sdk.Coins        `json:"supply"`
+		}
+		if err := remarshalJSON(bank, &state); err != nil {
+			return err
+		}
+
+		addresses := make([]string, 0, len(balances))
+		for address := range balances {
+			addresses = append(addresses, address)
+		}
+		sort.Strings(addresses)
+
+		for _, address := range addresses {
+			coins := balances[address]
+			state.Supply = state.Supply.Add(coins...)
+
+			found := false
+			for i, balance := range state.Balances {
+				if balance.Address == address {
+					state.Balances[i].Coins = balance.Coins.Add(coins...)
+					found = true
+					break
+				}
+			}
+			if !found {
+				state.Balances = append(state.Balances, genesisBalance{address, coins})
+			}
+		}
+
+		var updated map[string]interface{}
+		if err := remarshalJSON(state, &updated); err != nil {
+			return err
+		}
+		bank["balances"] = updated["balances"]
+		bank["supply"] = updated["supply"]
+
+		if communityPool.Empty() {
+			return nil
+		}
+		return addCommunityPool(appState, communityPool)
+	})
+}
+
+// addCommunityPool 將 coins 加入 appState 中 distribution 模塊的社區池。
+func addCommunityPool(appState map[string]interface{}, coins sdk.Coins) error {
+	distribution, ok := appState[distrtypes.ModuleName].(map[string]interface{})
+	if !ok {
+		return errors.New("創世中沒有 distribution 模塊的狀態")
+	}
+	feePool, ok := distribution["fee_pool"].(map[string]interface{})
+	if !ok {
+		feePool = make(map[string]interface{})
+		distribution["fee_pool"] = feePool
+	}
+
+	var pool sdk.DecCoins
+	if err := remarshalJSON(feePool["community_pool"], &pool); err != nil {
+		return err
+	}
+	pool = pool.Add(sdk.NewDecCoinsFromCoins(coins...)...)
+
+	var updated interface{}
+	if err := remarshalJSON(pool, &updated); err != nil {
+		return err
+	}
+	feePool["community_pool"] = updated
+	return nil
+}
+
+// remarshalJSON 通過 JSON 編碼將 src 轉換為 dst。
+func remarshalJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
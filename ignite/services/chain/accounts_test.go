@@ -0,0 +1,116 @@
+package chain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleAddress(t *testing.T) {
+	address, err := moduleAddress("distribution", "cosmos")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1jv65s3grqf6v6jl3dp4t6c9t9rk99cd88lyufl", address)
+
+	prefix, err := addressesPrefix(map[string]string{"alice": "mars1jv65s3grqf6v6jl3dp4t6c9t9rk99cd86za9uy"})
+	require.NoError(t, err)
+	require.Equal(t, "mars", prefix)
+}
+
+func TestAddGenesisBalances(t *testing.T) {
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	writeFiles(t, filepath.Dir(genesisPath), map[string]string{
+		"genesis.json": `{
+			"app_state": {
+				"bank": {
+					"params": {"default_send_enabled": true},
+					"balances": [{"address": "cosmos1alice", "coins": [{"denom": "stake", "amount": "100"}]}],
+					"supply": [{"denom": "stake", "amount": "100"}]
+				}
+			}
+		}`,
+	})
+
+	require.NoError(t, addGenesisBalances(genesisPath, map[string]sdk.Coins{
+		"cosmos1module": sdk.NewCoins(sdk.NewInt64Coin("token", 50)),
+		"cosmos1alice":  sdk.NewCoins(sdk.NewInt64Coin("stake", 10)),
+	}, nil))
+
+	data, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"app_state": {
+			"bank": {
+				"params": {"default_send_enabled": true},
+				"balances": [
+					{"address": "cosmos1alice", "coins": [{"denom": "stake", "amount": "110"}]},
+					{"address": "cosmos1module", "coins": [{"denom": "token", "amount": "50"}]}
+				],
+				"supply": [{"denom": "stake", "amount": "110"}, {"denom": "token", "amount": "50"}]
+			}
+		}
+	}`, string(data))
+}
+
+func TestAddGenesisBalancesCommunityPool(t *testing.T) {
+	distribution, err := moduleAddress("distribution", "cosmos")
+	require.NoError(t, err)
+
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	writeFiles(t, filepath.Dir(genesisPath), map[string]string{
+		"genesis.json": `{
+			"app_state": {
+				"bank": {
+					"balances": [{"address": "` + distribution + `", "coins": [{"denom": "stake", "amount": "5"}]}],
+					"supply": [{"denom": "stake", "amount": "5"}]
+				},
+				"distribution": {
+					"fee_pool": {"community_pool": [{"denom": "stake", "amount": "3.000000000000000000"}]},
+					"outstanding_rewards": [{"validator_address": "cosmosvaloper1a", "outstanding_rewards": [{"denom": "stake", "amount": "2.500000000000000000"}]}]
+				}
+			}
+		}`,
+	})
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 7))
+	require.NoError(t, addGenesisBalances(genesisPath, map[string]sdk.Coins{distribution: coins}, coins))
+
+	data, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	var genesis struct {
+		AppState struct {
+			Bank struct {
+				Balances []genesisBalance `json:"balances"`
+			} `json:"bank"`
+			Distribution struct {
+				FeePool struct {
+					CommunityPool sdk.DecCoins `json:"community_pool"`
+				} `json:"fee_pool"`
+				OutstandingRewards []struct {
+					OutstandingRewards sdk.DecCoins `json:"outstanding_rewards"`
+				} `json:"outstanding_rewards"`
+			} `json:"distribution"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(data, &genesis))
+
+	// the same check as the InitGenesis of the distribution module:
+	// the balance of the module account matches the community pool and the outstanding rewards.
+	holdings := genesis.AppState.Distribution.FeePool.CommunityPool
+	for _, rewards := range genesis.AppState.Distribution.OutstandingRewards {
+		holdings = holdings.Add(rewards.OutstandingRewards...)
+	}
+	moduleHoldings, _ := holdings.TruncateDecimal()
+
+	var balance sdk.Coins
+	for _, b := range genesis.AppState.Bank.Balances {
+		if b.Address == distribution {
+			balance = balance.Add(b.Coins...)
+		}
+	}
+	require.True(t, balance.IsEqual(moduleHoldings), "balance %s, holdings %s", balance, moduleHoldings)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 15), sdk.NewInt64Coin("token", 7)), balance)
+}
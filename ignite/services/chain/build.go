@@ -3,12 +3,10 @@ package chain
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/docker/docker/pkg/archive"
 	"github.com/pkg/errors"
 
 	"github.com/ignite-hq/cli/ignite/pkg/cache"
@@ -51,6 +49,8 @@ func (c *Chain) build(ctx context.Context, cacheStorage cache.Storage, output st
 		}
 	}()
 
+	c.emit(ServeEventBuildStarted, nil)
+
 	if err := c.generateAll(ctx, cacheStorage); err != nil {
 		return err
 	}
@@ -70,7 +70,17 @@ func (c *Chain) build(ctx context.Context, cacheStorage cache.Storage, output st
 		return err
 	}
 
-	return gocmd.BuildPath(ctx, output, binary, path, buildFlags)
+	if c.isDocker() {
+		err = c.dockerBuild(ctx, binary, path, buildFlags)
+	} else {
+		err = gocmd.BuildPath(ctx, output, binary, path, buildFlags)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.emit(ServeEventBuildFinished, map[string]interface{}{"binary": binary})
+	return nil
 }
 
 // BuildRelease 為發布構建二進製文件。目標是一個列表
@@ -89,7 +99,14 @@ func (c *Chain) BuildRelease(ctx context.Context, cacheStorage cache.Storage, ou
 		return "", err
 	}
 
-	buildFlags, err := c.preBuild(ctx, cacheStorage)
+	// 發布構建必須可以重現：去除本地路徑並固定構建 ID。
+	buildFlags, err := c.preBuild(ctx, cacheStorage, ldflagEmptyBuildID)
+	if err != nil {
+		return "", err
+	}
+	buildFlags = append(buildFlags, gocmd.FlagTrimpath)
+
+	info, err := c.releaseInfo(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -141,24 +158,40 @@ func (c *Chain) BuildRelease(ctx context.Context, cacheStorage cache.Storage, ou
 			return "", err
 		}
 
-		tarr, err := archive.Tar(out, archive.Gzip)
+		binaryChecksum, err := checksum.File(filepath.Join(out, binary))
 		if err != nil {
 			return "", err
 		}
 
-		tarName := fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
-		tarPath := filepath.Join(releasePath, tarName)
+		targetName := fmt.Sprintf("%s_%s_%s", prefix, goos, goarch)
+		tarName := targetName + ".tar.gz"
 
-		tarf, err := os.Create(tarPath)
-		if err != nil {
+		if err := writeReleaseTarball(filepath.Join(releasePath, tarName), out, info.sourceDate); err != nil {
 			return "", err
 		}
-		defer tarf.Close()
 
-		if _, err := io.Copy(tarf, tarr); err != nil {
+		// 為目標創建清單，以便獨立驗證二進製文件。
+		manifest := ReleaseManifest{
+			Name:            c.app.Name,
+			GOOS:            goos,
+			GOARCH:          goarch,
+			Binary:          binary,
+			BinaryChecksum:  binaryChecksum,
+			Tarball:         tarName,
+			GoVersion:       info.goVersion,
+			SDKVersion:      c.Version.Version,
+			Commit:          info.commit,
+			SourceDateEpoch: info.sourceDate.Unix(),
+			BuildFlags:      buildFlags,
+		}
+		if err := writeReleaseManifest(filepath.Join(releasePath, targetName+".json"), manifest); err != nil {
 			return "", err
 		}
-		tarf.Close()
+	}
+
+	sbomPath := filepath.Join(releasePath, fmt.Sprintf("%s_sbom.cdx.json", prefix))
+	if err := c.writeReleaseSBOM(sbomPath, info); err != nil {
+		return "", err
 	}
 
 	checksumPath := filepath.Join(releasePath, releaseChecksumKey)
@@ -167,7 +200,8 @@ func (c *Chain) BuildRelease(ctx context.Context, cacheStorage cache.Storage, ou
 	return releasePath, checksum.Sum(releasePath, checksumPath)
 }
 
-func (c *Chain) preBuild(ctx context.Context, cacheStorage cache.Storage) (buildFlags []string, err error) {
+// preBuild 準備構建並返回 go build 的參數，extraLDFlags 會被添加到鏈接器參數中。
+func (c *Chain) preBuild(ctx context.Context, cacheStorage cache.Storage, extraLDFlags ...string) (buildFlags []string, err error) {
 	config, err := c.Config()
 	if err != nil {
 		return nil, err
@@ -186,6 +220,7 @@ func (c *Chain) preBuild(ctx context.Context, cacheStorage cache.Storage) (build
 		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
 		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
 	)
+	ldFlags = append(ldFlags, extraLDFlags...)
 	buildFlags = []string{
 		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
 		gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...),
@@ -195,7 +230,12 @@ func (c *Chain) preBuild(ctx context.Context, cacheStorage cache.Storage) (build
 
 	// 我們在檢查校驗和更改之前做 mod tidy，因為 go.mod 經常被修改
 	// 無論如何，mod verify 命令是昂貴的
-	if err := gocmd.ModTidy(ctx, c.app.Path); err != nil {
+	if c.isDocker() {
+		err = c.dockerGo(ctx, c.app.Path, gocmd.CommandMod, gocmd.CommandModTidy)
+	} else {
+		err = gocmd.ModTidy(ctx, c.app.Path)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -206,7 +246,12 @@ func (c *Chain) preBuild(ctx context.Context, cacheStorage cache.Storage) (build
 	}
 
 	if modChanged {
-		if err := gocmd.ModVerify(ctx, c.app.Path); err != nil {
+		if c.isDocker() {
+			err = c.dockerGo(ctx, c.app.Path, gocmd.CommandMod, gocmd.CommandModVerify)
+		} else {
+			err = gocmd.ModVerify(ctx, c.app.Path)
+		}
+		if err != nil {
 			return nil, err
 		}
 
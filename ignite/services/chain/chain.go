@@ -16,6 +16,7 @@ import (
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite-hq/cli/ignite/pkg/confile"
 	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
+	"github.com/ignite-hq/cli/ignite/pkg/nodelog"
 	"github.com/ignite-hq/cli/ignite/pkg/repoversion"
 	"github.com/ignite-hq/cli/ignite/pkg/xurl"
 )
@@ -58,6 +59,7 @@ type Chain struct {
 	plugin         Plugin
 	sourceVersion  version
 	logLevel       LogLvl
+	logFilter      nodelog.Filter
 	serveCancel    context.CancelFunc
 	serveRefresher chan struct{}
 	served         bool
@@ -65,7 +67,28 @@ type Chain struct {
 	// protoBuiltAtLeastOnce 表示應用程序的原型生成至少生成一次。
 	protoBuiltAtLeastOnce bool
 
+	// events 在啟用時接收 serve 期間的生命週期事件。
+	events *eventWriter
+
+	// status 根據生命週期事件記錄 serve 的階段，供狀態端點使用。
+	status *serveStatus
+
+	// cosmovisor 表示節點在 cosmovisor 下啟動。
+	cosmovisor bool
+
+	// target 是構建和運行節點的目標，為空時與 TargetLocal 相同。
+	target string
+
+	// reloadTrigger 在不為空時代替標準輸入觸發手動重新加載。
+	reloadTrigger <-chan struct{}
+
+	// dockerImage 是已構建的工具鏈鏡像，為空時在第一次需要時構建。
+	dockerImage string
+
 	stdout, stderr io.Writer
+
+	// out 和 errOut 是常規和詳細日誌級別下輸出的目標。
+	out, errOut io.Writer
 }
 
 // chainOptions 包含覆蓋鏈默認值的用戶給定選項。
@@ -85,6 +108,15 @@ type chainOptions struct {
 
 	// 自定義配置文件的路徑
 	ConfigFile string
+
+	// profile 是合併到配置文件之上的配置檔案名稱
+	profile string
+
+	// configOverride 在讀取配置後修改配置
+	configOverride func(*chainconfig.Config)
+
+	// binaryDir 是鏈的二進製文件所在的目錄，為空時在 PATH 中查找二進製文件
+	binaryDir string
 }
 
 // 選項配置鏈。
@@ -97,6 +129,14 @@ func LogLevel(level LogLvl) Option {
 	}
 }
 
+// LogFilter 設置 serve 期間節點日誌按模塊過濾的級別，例如 x/bank=debug,consensus=error。
+// 設置後即使不在詳細模式下，保留的節點日誌也會輸出到終端。
+func LogFilter(filter nodelog.Filter) Option {
+	return func(c *Chain) {
+		c.logFilter = filter
+	}
+}
+
 //ID 用給定的 id 替換鏈的 id。
 func ID(id string) Option {
 	return func(c *Chain) {
@@ -111,6 +151,13 @@ func HomePath(path string) Option {
 	}
 }
 
+// BinaryDir 使鏈的命令運行 dir 中的二進製文件，而不是在 PATH 中查找二進製文件。
+func BinaryDir(dir string) Option {
+	return func(c *Chain) {
+		c.options.binaryDir = dir
+	}
+}
+
 // KeyringBackend指定用於鏈命令的密鑰環後端
 func KeyringBackend(keyringBackend chaincmd.KeyringBackend) Option {
 	return func(c *Chain) {
@@ -125,6 +172,37 @@ func ConfigFile(configFile string) Option {
 	}
 }
 
+// EventOutput 將 serve 期間的生命週期事件以換行分隔的 JSON 格式寫入 w。
+func EventOutput(w io.Writer) Option {
+	return func(c *Chain) {
+		c.events = newEventWriter(w)
+	}
+}
+
+// Profile 指定要合併到配置文件之上的配置檔案，例如 dev 對應 config.dev.yml。
+// 未指定時使用 chainconfig.ProfileEnvVar 環境變量。
+func Profile(profile string) Option {
+	return func(c *Chain) {
+		c.options.profile = profile
+	}
+}
+
+// ConfigOverride 在每次讀取鏈的配置後使用 override 修改配置，例如在工作區中為每條鏈分配不衝突的端口。
+// 修改不會寫入配置文件。
+func ConfigOverride(override func(conf *chainconfig.Config)) Option {
+	return func(c *Chain) {
+		c.options.configOverride = override
+	}
+}
+
+// Output 設置常規和詳細日誌級別下輸出的目標，默認為標準輸出和標準錯誤。
+func Output(stdout, stderr io.Writer) Option {
+	return func(c *Chain) {
+		c.out = stdout
+		c.errOut = stderr
+	}
+}
+
 // EnableThirdPartyModuleCodegen 啟用第三方模塊的代碼生成，
 // 包括 SDK。
 func EnableThirdPartyModuleCodegen() Option {
@@ -144,8 +222,11 @@ func New(path string, options ...Option) (*Chain, error) {
 		app:            app,
 		logLevel:       LogSilent,
 		serveRefresher: make(chan struct{}, 1),
+		status:         &serveStatus{},
 		stdout:         io.Discard,
 		stderr:         io.Discard,
+		out:            os.Stdout,
+		errOut:         os.Stderr,
 	}
 
 	// 應用選項
@@ -154,8 +235,8 @@ func New(path string, options ...Option) (*Chain, error) {
 	}
 
 	if c.logLevel == LogVerbose {
-		c.stdout = os.Stdout
-		c.stderr = os.Stderr
+		c.stdout = c.out
+		c.stderr = c.errOut
 	}
 
 	c.sourceVersion, err = c.appVersion()
@@ -218,13 +299,50 @@ func (c *Chain) ConfigPath() string {
 	return path
 }
 
-// Config 返回鏈的配置
-func (c *Chain) Config() (chainconfig.Config, error) {
+// Profile 返回合併到配置文件之上的配置檔案名稱，空字符串表示沒有使用配置檔案
+func (c *Chain) Profile() string {
+	if c.options.profile != "" {
+		return c.options.profile
+	}
+	return os.Getenv(chainconfig.ProfileEnvVar)
+}
+
+// ConfigPaths 返回鏈使用的所有配置文件的路徑，包括配置檔案
+func (c *Chain) ConfigPaths() []string {
 	configPath := c.ConfigPath()
 	if configPath == "" {
-		return chainconfig.DefaultConf, nil
+		return nil
 	}
-	return chainconfig.ParseFile(configPath)
+	paths := []string{configPath}
+	if profile := c.Profile(); profile != "" {
+		paths = append(paths, chainconfig.ProfileFilePath(configPath, profile))
+	}
+	return paths
+}
+
+// Config 返回鏈的配置
+func (c *Chain) Config() (chainconfig.Config, error) {
+	conf := chainconfig.DefaultConf
+	if configPath := c.ConfigPath(); configPath != "" {
+		var err error
+		if conf, err = chainconfig.ParseFileWithProfile(configPath, c.Profile()); err != nil {
+			return chainconfig.Config{}, err
+		}
+	}
+	if c.options.configOverride != nil {
+		c.options.configOverride(&conf)
+	}
+	return conf, nil
+}
+
+// ValidateConfig 嚴格驗證鏈使用的所有配置文件，包括配置檔案。
+func (c *Chain) ValidateConfig() error {
+	for _, path := range c.ConfigPaths() {
+		if err := chainconfig.ValidateFile(path); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ID 返回鏈的 id。
@@ -330,7 +448,7 @@ func (c *Chain) GenesisPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "config/genesis.json"), nil
+	return nodeGenesisPath(home), nil
 }
 
 // GentxsPath 返回為應用程序存儲 gentxs 的目錄。
@@ -348,7 +466,7 @@ func (c *Chain) AppTOMLPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "config/app.toml"), nil
+	return nodeAppTOMLPath(home), nil
 }
 
 // ConfigTOMLPath 返回應用程序的 config.toml 路徑。
@@ -357,7 +475,7 @@ func (c *Chain) ConfigTOMLPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "config/config.toml"), nil
+	return nodeConfigTOMLPath(home), nil
 }
 
 // ClientTOMLPath 返回應用程序的 client.toml 路徑。
@@ -366,7 +484,27 @@ func (c *Chain) ClientTOMLPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "config/client.toml"), nil
+	return nodeClientTOMLPath(home), nil
+}
+
+// nodeGenesisPath 返回主目錄為 home 的節點的 genesis.json 路徑。
+func nodeGenesisPath(home string) string {
+	return filepath.Join(home, "config/genesis.json")
+}
+
+// nodeAppTOMLPath 返回主目錄為 home 的節點的 app.toml 路徑。
+func nodeAppTOMLPath(home string) string {
+	return filepath.Join(home, "config/app.toml")
+}
+
+// nodeConfigTOMLPath 返回主目錄為 home 的節點的 config.toml 路徑。
+func nodeConfigTOMLPath(home string) string {
+	return filepath.Join(home, "config/config.toml")
+}
+
+// nodeClientTOMLPath 返回主目錄為 home 的節點的 client.toml 路徑。
+func nodeClientTOMLPath(home string) string {
+	return filepath.Join(home, "config/client.toml")
 }
 
 // KeyringBackend 返回為鏈選擇的密鑰環後端。
@@ -417,32 +555,37 @@ func (c *Chain) KeyringBackend() (chaincmd.KeyringBackend, error) {
 
 // Commands 返回運行者在鏈的二進製文件上執行命令
 func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
-	id, err := c.ID()
+	home, err := c.Home()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	home, err := c.Home()
+	config, err := c.Config()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	binary, err := c.Binary()
+	return c.commands(ctx, home, config.Host.RPC)
+}
+
+// commands 返回在給定主目錄和 RPC 地址的節點上執行命令的運行者。
+func (c *Chain) commands(ctx context.Context, home, rpcAddress string) (chaincmdrunner.Runner, error) {
+	id, err := c.ID()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	backend, err := c.KeyringBackend()
+	binary, err := c.Binary()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	config, err := c.Config()
+	backend, err := c.KeyringBackend()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	nodeAddr, err := xurl.TCP(config.Host.RPC)
+	nodeAddr, err := xurl.TCP(rpcAddress)
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
@@ -455,13 +598,34 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 		chaincmd.WithKeyringBackend(backend),
 	}
 
+	if c.cosmovisor {
+		chainCommandOptions = append(chainCommandOptions, chaincmd.WithCosmovisor())
+	}
+
+	// 在容器中運行容器構建的二進製文件
+	if c.isDocker() {
+		binDir, err := c.dockerBinDir()
+		if err != nil {
+			return chaincmdrunner.Runner{}, err
+		}
+		binary = filepath.Join(binDir, binary)
+
+		containerOption, err := c.containerOption(ctx, home)
+		if err != nil {
+			return chaincmdrunner.Runner{}, err
+		}
+		chainCommandOptions = append(chainCommandOptions, containerOption)
+	} else if c.options.binaryDir != "" {
+		binary = filepath.Join(c.options.binaryDir, binary)
+	}
+
 	cc := chaincmd.New(binary, chainCommandOptions...)
 
 	ccrOptions := make([]chaincmdrunner.Option, 0)
 	if c.logLevel == LogVerbose {
 		ccrOptions = append(ccrOptions,
-			chaincmdrunner.Stdout(os.Stdout),
-			chaincmdrunner.Stderr(os.Stderr),
+			chaincmdrunner.Stdout(c.out),
+			chaincmdrunner.Stderr(c.errOut),
 			chaincmdrunner.DaemonLogPrefix(c.genPrefix(logAppd)),
 		)
 	}
@@ -43,3 +43,21 @@ func tempSource(t *testing.T, tarPath string) (path string) {
 
 	return filepath.Join(dir, dirs[0].Name())
 }
+
+func TestBinaryDir(t *testing.T) {
+	binDir := t.TempDir()
+	c, err := New(tempSource(t, "testdata/version/mars.v0.2.tar.gz"), BinaryDir(binDir))
+	require.NoError(t, err)
+
+	// the binary is not looked up in PATH.
+	_, err = c.binaryPath()
+	require.Error(t, err)
+
+	binary, err := c.Binary()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, binary), []byte("#!/bin/sh\n"), 0755))
+
+	path, err := c.binaryPath()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(binDir, binary), path)
+}
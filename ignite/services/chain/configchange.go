@@ -0,0 +1,196 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/checksum"
+	"github.com/ignite-hq/cli/ignite/pkg/dirchange"
+)
+
+const (
+	// configStateChecksumKey 是包含影響鏈狀態的配置校驗和的緩存鍵
+	configStateChecksumKey = "config_state_checksum"
+
+	// configBuildChecksumKey 是包含影響構建的配置校驗和的緩存鍵
+	configBuildChecksumKey = "config_build_checksum"
+)
+
+// configChange 表示 config.yml 自上次服務以來的修改類型，
+// 值越大表示修改的影響越大。
+type configChange int
+
+const (
+	// configUnchanged 表示配置沒有修改。
+	configUnchanged configChange = iota
+
+	// configRuntimeChanged 表示只有 init.app、init.config、init.client、
+	// 水龍頭或主機配置被修改，節點可以在保留狀態的情況下重新配置並重啟。
+	configRuntimeChanged
+
+	// configBuildChanged 表示構建或客戶端代碼生成配置被修改，應用必須重新構建。
+	configBuildChanged
+
+	// configStateChanged 表示創世、帳戶或驗證者配置被修改，鏈狀態必須重置。
+	configStateChanged
+)
+
+// configStateSection 保存修改後必須重置鏈狀態的配置部分。
+type configStateSection struct {
+	Accounts       []chainconfig.Account
+	Validator      chainconfig.Validator
+	Validators     []chainconfig.Validator
+	Genesis        map[string]interface{}
+	Fixtures       []byte
+	Home           string
+	KeyringBackend string
+}
+
+// configBuildSection 保存修改後必須重新構建應用的配置部分。
+type configBuildSection struct {
+	Build  chainconfig.Build
+	Client chainconfig.Client
+}
+
+// detectConfigChange 比較 config.yml 與上次服務時保存的校驗和，並返回修改的類型。
+// 當找不到已保存的部分校驗和時，修改被視為影響狀態。
+func (c *Chain) detectConfigChange(dirCache cache.Cache[[]byte], conf chainconfig.Config) (configChange, error) {
+	modified, err := dirchange.HasDirChecksumChanged(dirCache, configChecksumKey, c.app.Path, c.configChangePaths(conf)...)
+	if err != nil || !modified {
+		return configUnchanged, err
+	}
+
+	fixturesSum, err := c.genesisFixturesChecksum(conf)
+	if err != nil {
+		return configUnchanged, err
+	}
+
+	stateSum, buildSum, err := configSectionChecksums(conf, fixturesSum)
+	if err != nil {
+		return configUnchanged, err
+	}
+
+	savedStateSum, err := dirCache.Get(configStateChecksumKey)
+	if err == cache.ErrorNotFound {
+		return configStateChanged, nil
+	}
+	if err != nil {
+		return configUnchanged, err
+	}
+	if string(savedStateSum) != string(stateSum) {
+		return configStateChanged, nil
+	}
+
+	savedBuildSum, err := dirCache.Get(configBuildChecksumKey)
+	if err == cache.ErrorNotFound {
+		return configBuildChanged, nil
+	}
+	if err != nil {
+		return configUnchanged, err
+	}
+	if string(savedBuildSum) != string(buildSum) {
+		return configBuildChanged, nil
+	}
+
+	return configRuntimeChanged, nil
+}
+
+// saveConfigChecksums 保存 config.yml 及其各部分的校驗和。
+func (c *Chain) saveConfigChecksums(dirCache cache.Cache[[]byte], conf chainconfig.Config) error {
+	if err := dirchange.SaveDirChecksum(dirCache, configChecksumKey, c.app.Path, c.configChangePaths(conf)...); err != nil {
+		return err
+	}
+
+	fixturesSum, err := c.genesisFixturesChecksum(conf)
+	if err != nil {
+		return err
+	}
+
+	stateSum, buildSum, err := configSectionChecksums(conf, fixturesSum)
+	if err != nil {
+		return err
+	}
+
+	if err := dirCache.Put(configStateChecksumKey, stateSum); err != nil {
+		return err
+	}
+
+	return dirCache.Put(configBuildChecksumKey, buildSum)
+}
+
+// configChangePaths 返回修改後需要檢查配置變化的文件，包括配置文件和創世數據。
+func (c *Chain) configChangePaths(conf chainconfig.Config) []string {
+	return append(c.ConfigPaths(), conf.GenesisFixtures...)
+}
+
+// genesisFixturesChecksum 返回創世數據文件內容的校驗和，沒有創世數據時返回 nil。
+func (c *Chain) genesisFixturesChecksum(conf chainconfig.Config) ([]byte, error) {
+	if len(conf.GenesisFixtures) == 0 {
+		return nil, nil
+	}
+	sum, err := dirchange.ChecksumFromPaths(c.app.Path, conf.GenesisFixtures...)
+	if err == dirchange.ErrNoFile {
+		return nil, nil
+	}
+	return sum, err
+}
+
+// configSectionChecksums 返回配置中影響狀態和影響構建部分的校驗和，
+// fixturesSum 是創世數據文件內容的校驗和。
+func configSectionChecksums(conf chainconfig.Config, fixturesSum []byte) (stateSum, buildSum []byte, err error) {
+	state, err := json.Marshal(configStateSection{
+		Accounts:       conf.Accounts,
+		Validator:      conf.Validator,
+		Validators:     conf.Validators,
+		Genesis:        conf.Genesis,
+		Fixtures:       fixturesSum,
+		Home:           conf.Init.Home,
+		KeyringBackend: conf.Init.KeyringBackend,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 監視配置不影響構建結果
+	buildConf := conf.Build
+	buildConf.Watch = chainconfig.Watch{}
+
+	build, err := json.Marshal(configBuildSection{
+		Build:  buildConf,
+		Client: conf.Client,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []byte(checksum.Strings(string(state))), []byte(checksum.Strings(string(build))), nil
+}
+
+// reconfigure 在不重置狀態的情況下，將 config.yml 中的節點配置重新應用到所有驗證者節點。
+func (c *Chain) reconfigure(ctx context.Context, conf chainconfig.Config) error {
+	nodes, err := c.validatorNodes(conf)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		nodeConf := conf
+		nodeConf.Host = n.host
+
+		if err := c.plugin.Configure(n.home, nodeConf); err != nil {
+			return err
+		}
+
+		if err := overrideTOMLConfigs(n.home, conf); err != nil {
+			return err
+		}
+	}
+
+	if len(nodes) > 1 {
+		return c.connectValidatorNodes(ctx, nodes)
+	}
+
+	return nil
+}
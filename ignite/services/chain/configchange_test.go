@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+)
+
+func TestDetectConfigChange(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		configPath = filepath.Join(dir, "config.yml")
+		c          = &Chain{
+			app:     App{Path: dir},
+			options: chainOptions{ConfigFile: configPath},
+		}
+	)
+
+	storage, err := cache.NewStorage(filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err)
+	dirCache := cache.New[[]byte](storage, serveDirchangeCacheNamespace)
+
+	writeConfig := func(yml string) chainconfig.Config {
+		require.NoError(t, os.WriteFile(configPath, []byte(yml), 0644))
+		conf, err := chainconfig.ParseFile(configPath)
+		require.NoError(t, err)
+		return conf
+	}
+
+	base := `
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validator:
+  name: alice
+  staked: "100000000stake"
+`
+
+	// without saved checksums any modification is considered state breaking.
+	conf := writeConfig(base)
+	change, err := c.detectConfigChange(dirCache, conf)
+	require.NoError(t, err)
+	require.Equal(t, configStateChanged, change)
+	require.NoError(t, c.saveConfigChecksums(dirCache, conf))
+
+	change, err = c.detectConfigChange(dirCache, conf)
+	require.NoError(t, err)
+	require.Equal(t, configUnchanged, change)
+
+	conf = writeConfig(base + `
+faucet:
+  name: alice
+  coins: ["5stake"]
+init:
+  app:
+    minimum-gas-prices: "0stake"
+`)
+	change, err = c.detectConfigChange(dirCache, conf)
+	require.NoError(t, err)
+	require.Equal(t, configRuntimeChanged, change)
+	require.NoError(t, c.saveConfigChecksums(dirCache, conf))
+
+	conf = writeConfig(base + `
+build:
+  binary: "alphad"
+`)
+	change, err = c.detectConfigChange(dirCache, conf)
+	require.NoError(t, err)
+	require.Equal(t, configBuildChanged, change)
+
+	conf = writeConfig(base + `
+genesis:
+  chain_id: "alpha"
+`)
+	change, err = c.detectConfigChange(dirCache, conf)
+	require.NoError(t, err)
+	require.Equal(t, configStateChanged, change)
+}
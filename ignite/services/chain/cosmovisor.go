@@ -0,0 +1,181 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/otiai10/copy"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+)
+
+const (
+	// CosmovisorGenesis 是將二進製文件佈置為 cosmovisor 創世二進製文件的升級名稱。
+	CosmovisorGenesis = "genesis"
+
+	// cosmovisorDir 是 cosmovisor 在鏈主目錄中的目錄名稱
+	cosmovisorDir = "cosmovisor"
+
+	// cosmovisorUpgradesDir 是保存升級二進製文件的目錄名稱
+	cosmovisorUpgradesDir = "upgrades"
+
+	// cosmovisorCurrent 是 cosmovisor 指向當前二進製文件的符號鏈接名稱
+	cosmovisorCurrent = "current"
+
+	// upgradeInfoFile 是描述升級的文件名稱
+	upgradeInfoFile = "upgrade-info.json"
+)
+
+var upgradeNameRe = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// UpgradeInfo 是 upgrade-info.json 的內容，節點在升級高度將它寫入數據目錄，
+// cosmovisor 根據其中的名稱切換到對應的升級二進製文件。
+type UpgradeInfo struct {
+	// Name 是升級的名稱，必須與升級計劃的名稱相同。
+	Name string `json:"name"`
+
+	// Height 是執行升級的區塊高度。
+	Height int64 `json:"height"`
+
+	// Info 是升級計劃的附加信息。
+	Info string `json:"info"`
+}
+
+// BuildCosmovisor 構建應用程序二進製文件並按 cosmovisor 的目錄結構將其放在鏈主目錄中。
+// upgradeName 為 CosmovisorGenesis 時，二進製文件被放在 cosmovisor/genesis/bin 中，
+// 否則放在 cosmovisor/upgrades/<upgradeName>/bin 中並創建 upgrade-info.json 模板。
+func (c *Chain) BuildCosmovisor(ctx context.Context, cacheStorage cache.Storage, upgradeName string) (binaryPath string, err error) {
+	if err := c.setup(); err != nil {
+		return "", err
+	}
+
+	homes, err := c.cosmovisorHomes()
+	if err != nil {
+		return "", err
+	}
+
+	binDir, err := cosmovisorBinDir(homes[0], upgradeName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := c.build(ctx, cacheStorage, binDir); err != nil {
+		return "", err
+	}
+
+	binary, err := c.Binary()
+	if err != nil {
+		return "", err
+	}
+	binaryPath = filepath.Join(binDir, binary)
+
+	for _, home := range homes {
+		if err := c.layoutCosmovisorBinary(home, upgradeName, binaryPath); err != nil {
+			return "", err
+		}
+	}
+
+	return binaryPath, nil
+}
+
+// cosmovisorHomes 返回需要 cosmovisor 目錄結構的主目錄，第一個為鏈的主目錄，
+// 其後為已經初始化的其他驗證者節點的主目錄。
+func (c *Chain) cosmovisorHomes() ([]string, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	homes := []string{home}
+	for _, v := range conf.ListValidators()[1:] {
+		nodeHome := validatorNodeHome(home, v.Name)
+		if _, err := os.Stat(nodeHome); err == nil {
+			homes = append(homes, nodeHome)
+		}
+	}
+
+	return homes, nil
+}
+
+// layoutCosmovisorBinary 將二進製文件複製到主目錄 home 中名為 upgradeName 的 cosmovisor 目錄，
+// 對於升級，還會在二進製文件旁邊創建 upgrade-info.json 模板。
+func (c *Chain) layoutCosmovisorBinary(home, upgradeName, binaryPath string) error {
+	binDir, err := cosmovisorBinDir(home, upgradeName)
+	if err != nil {
+		return err
+	}
+
+	dst := filepath.Join(binDir, filepath.Base(binaryPath))
+	if dst != binaryPath {
+		if err := copy.Copy(binaryPath, dst); err != nil {
+			return err
+		}
+	}
+
+	if upgradeName == CosmovisorGenesis {
+		return nil
+	}
+
+	// 不覆蓋已經編輯過的模板。
+	infoPath := filepath.Join(filepath.Dir(binDir), upgradeInfoFile)
+	if _, err := os.Stat(infoPath); err == nil {
+		return nil
+	}
+
+	cf := confile.New(confile.DefaultJSONEncodingCreator, infoPath)
+	return cf.Save(UpgradeInfo{Name: upgradeName})
+}
+
+// prepareCosmovisor 將已安裝的二進製文件作為創世二進製文件放到所有驗證者節點的 cosmovisor 目錄中。
+// 鏈狀態被重置時，還會刪除 cosmovisor 的當前二進製文件鏈接和升級信息，使鏈從創世二進製文件啟動。
+func (c *Chain) prepareCosmovisor(binaryPath string, stateReset bool) error {
+	homes, err := c.cosmovisorHomes()
+	if err != nil {
+		return err
+	}
+
+	for _, home := range homes {
+		if err := c.layoutCosmovisorBinary(home, CosmovisorGenesis, binaryPath); err != nil {
+			return err
+		}
+
+		if !stateReset {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(home, cosmovisorDir, cosmovisorCurrent)); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(home, "data", upgradeInfoFile)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cosmovisorBinDir 返回主目錄 home 中名為 upgradeName 的 cosmovisor 二進製文件目錄。
+func cosmovisorBinDir(home, upgradeName string) (string, error) {
+	if upgradeName == CosmovisorGenesis {
+		return filepath.Join(home, cosmovisorDir, CosmovisorGenesis, "bin"), nil
+	}
+
+	if !upgradeNameRe.MatchString(upgradeName) {
+		return "", fmt.Errorf("無效的升級名稱 %q，只允許字母、數字、'.'、'_' 和 '-'", upgradeName)
+	}
+
+	return filepath.Join(home, cosmovisorDir, cosmovisorUpgradesDir, upgradeName, "bin"), nil
+}
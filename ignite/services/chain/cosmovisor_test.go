@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+)
+
+func TestLayoutCosmovisorBinary(t *testing.T) {
+	var (
+		c          = &Chain{}
+		home       = t.TempDir()
+		binaryPath = filepath.Join(t.TempDir(), "marsd")
+	)
+	require.NoError(t, os.WriteFile(binaryPath, []byte("binary"), 0755))
+
+	require.NoError(t, c.layoutCosmovisorBinary(home, CosmovisorGenesis, binaryPath))
+	require.FileExists(t, filepath.Join(home, "cosmovisor/genesis/bin/marsd"))
+	require.NoFileExists(t, filepath.Join(home, "cosmovisor/genesis", upgradeInfoFile))
+
+	require.NoError(t, c.layoutCosmovisorBinary(home, "v2", binaryPath))
+	require.FileExists(t, filepath.Join(home, "cosmovisor/upgrades/v2/bin/marsd"))
+
+	var info UpgradeInfo
+	cf := confile.New(confile.DefaultJSONEncodingCreator, filepath.Join(home, "cosmovisor/upgrades/v2", upgradeInfoFile))
+	require.NoError(t, cf.Load(&info))
+	require.Equal(t, UpgradeInfo{Name: "v2"}, info)
+
+	require.Error(t, c.layoutCosmovisorBinary(home, "../v3", binaryPath))
+}
@@ -0,0 +1,314 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
+	"github.com/ignite-hq/cli/ignite/pkg/checksum"
+	cmdexec "github.com/ignite-hq/cli/ignite/pkg/cmdrunner/exec"
+	"github.com/ignite-hq/cli/ignite/pkg/cmdrunner/step"
+	"github.com/ignite-hq/cli/ignite/pkg/gocmd"
+	"github.com/ignite-hq/cli/ignite/pkg/xfilepath"
+)
+
+const (
+	// TargetLocal 使用主機上的 Go 工具鏈構建二進製文件並在主機上運行節點。
+	TargetLocal = "local"
+
+	// TargetDocker 在容器中構建二進製文件並在容器中運行節點。
+	TargetDocker = "docker"
+
+	// dockerRuntime 是運行容器使用的命令，可以是任何與 Docker 兼容的容器運行時。
+	dockerRuntime = "docker"
+
+	// dockerfileName 是應用程序根目錄中默認使用的 Dockerfile。
+	dockerfileName = "Dockerfile"
+
+	// dockerGoCache 和 dockerGoModCache 是 Go 構建緩存和模塊緩存在容器數據目錄中的目錄。
+	dockerGoCache    = "go-build"
+	dockerGoModCache = "go-mod"
+)
+
+// defaultToolchainDockerfile 是應用程序沒有 Dockerfile 時構建工具鏈鏡像使用的 Dockerfile，
+// 與 Ignite 的 Dockerfile 的 base 階段相同。
+const defaultToolchainDockerfile = `FROM golang:1.18.0-bullseye
+
+RUN apt update && \
+    apt-get install -y \
+        build-essential \
+        ca-certificates \
+        curl
+
+ENV GOPROXY https://proxy.golang.org
+`
+
+// dockerPath 是保存容器構建的二進製文件和 Go 緩存的目錄。
+var dockerPath = xfilepath.Join(
+	chainconfig.ConfigDirPath,
+	xfilepath.Path("docker"),
+)
+
+// Targets 返回 serve 支持的目標。
+func Targets() []string {
+	return []string{TargetLocal, TargetDocker}
+}
+
+// ServeTarget 設置構建和運行節點的目標，默認為 TargetLocal。
+// 使用 TargetDocker 時，二進製文件在從應用程序的 Dockerfile 構建的工具鏈鏡像中構建，
+// 節點在同一鏡像的容器中運行，鏈的主目錄被掛載到容器中。
+func ServeTarget(target string) ServeOption {
+	return func(c *serveOptions) {
+		c.target = target
+	}
+}
+
+// isDocker 表示二進製文件在容器中構建和運行。
+func (c *Chain) isDocker() bool {
+	return c.target == TargetDocker
+}
+
+// checkTarget 檢查 serve 的目標是否受支持。
+func (c *Chain) checkTarget() error {
+	switch c.target {
+	case "", TargetLocal:
+		return nil
+	case TargetDocker:
+		if c.cosmovisor {
+			return errors.New("docker 目標不支持 cosmovisor")
+		}
+		if _, err := exec.LookPath(dockerRuntime); err != nil {
+			return errors.Wrapf(err, "docker 目標需要 %s 命令", dockerRuntime)
+		}
+		return nil
+	default:
+		return fmt.Errorf("不支持的目標 %q，可用的目標: %s", c.target, strings.Join(Targets(), ", "))
+	}
+}
+
+// dockerDir 返回保存應用程序的容器構建的二進製文件和 Go 緩存的目錄。
+func (c *Chain) dockerDir() (string, error) {
+	path, err := dockerPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(path, c.app.N()), nil
+}
+
+// dockerBinDir 返回保存容器構建的二進製文件的目錄。
+func (c *Chain) dockerBinDir() (string, error) {
+	dir, err := c.dockerDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bin"), nil
+}
+
+// binaryPath 返回應用程序二進製文件的路徑，二進製文件不存在時返回 exec.ErrNotFound。
+func (c *Chain) binaryPath() (string, error) {
+	binary, err := c.Binary()
+	if err != nil {
+		return "", err
+	}
+	if !c.isDocker() {
+		if c.options.binaryDir != "" {
+			return exec.LookPath(filepath.Join(c.options.binaryDir, binary))
+		}
+		return exec.LookPath(binary)
+	}
+
+	binDir, err := c.dockerBinDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(binDir, binary)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", exec.ErrNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// dockerUser 返回容器使用的 uid:gid，使容器寫入掛載目錄的文件屬於當前用戶。
+func dockerUser() string {
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid < 0 || gid < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", uid, gid)
+}
+
+// toolchainImage 構建應用程序的工具鏈鏡像並返回其名稱，鏡像已存在時不重新構建。
+// 鏡像的標籤是 Dockerfile 和構建階段的校驗和，修改 Dockerfile 後會構建新的鏡像。
+func (c *Chain) toolchainImage(ctx context.Context) (string, error) {
+	if c.dockerImage != "" {
+		return c.dockerImage, nil
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	dockerfile := conf.Build.Docker.Dockerfile
+	if dockerfile == "" {
+		dockerfile = dockerfileName
+	}
+	dockerfilePath := filepath.Join(c.app.Path, dockerfile)
+
+	content, err := os.ReadFile(dockerfilePath)
+	switch {
+	case os.IsNotExist(err) && conf.Build.Docker.Dockerfile == "":
+		content, dockerfilePath = []byte(defaultToolchainDockerfile), ""
+	case err != nil:
+		return "", err
+	}
+
+	image := fmt.Sprintf(
+		"ignite-%s-toolchain:%s",
+		strings.ToLower(c.app.N()),
+		checksum.Strings(string(content), conf.Build.Docker.Target)[:12],
+	)
+
+	if err := cmdexec.Exec(ctx, []string{dockerRuntime, "image", "inspect", image}); err == nil {
+		c.dockerImage = image
+		return image, nil
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🐳 構建工具鏈鏡像 %s...\n", image)
+
+	command := []string{dockerRuntime, "build", "-t", image}
+	if conf.Build.Docker.Target != "" {
+		command = append(command, "--target", conf.Build.Docker.Target)
+	}
+	options := []cmdexec.Option{
+		cmdexec.StepOption(step.Env("DOCKER_BUILDKIT=1")),
+		cmdexec.IncludeStdLogsToError(),
+	}
+	if dockerfilePath != "" {
+		command = append(command, "-f", dockerfilePath, c.app.Path)
+	} else {
+		// 默認的 Dockerfile 不需要構建上下文，從標準輸入讀取
+		command = append(command, "-")
+		options = append(options, cmdexec.StepOption(step.Stdin(bytes.NewReader(content))))
+	}
+
+	if err := cmdexec.Exec(ctx, command, options...); err != nil {
+		return "", &CannotBuildAppError{err}
+	}
+
+	c.dockerImage = image
+	return image, nil
+}
+
+// dockerGo 在工具鏈鏡像的容器中以 workdir 為工作目錄運行 go 命令。
+// 應用程序目錄和 Go 緩存被掛載到容器中，在多次構建之間保留緩存。
+func (c *Chain) dockerGo(ctx context.Context, workdir string, args ...string) error {
+	image, err := c.toolchainImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	dir, err := c.dockerDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := c.dockerBinDir()
+	if err != nil {
+		return err
+	}
+	goCache := filepath.Join(dir, dockerGoCache)
+	goModCache := filepath.Join(dir, dockerGoModCache)
+	for _, path := range []string{binDir, goCache, goModCache} {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return err
+		}
+	}
+
+	command := []string{dockerRuntime, "run", "--rm"}
+	if user := dockerUser(); user != "" {
+		command = append(command, "--user", user)
+	}
+	command = append(command,
+		"-e", "HOME=/tmp",
+		"-e", "GOCACHE="+goCache,
+		"-e", "GOMODCACHE="+goModCache,
+		"-e", "GOFLAGS=-buildvcs=false",
+		"-v", fmt.Sprintf("%s:%s", dir, dir),
+		"-v", fmt.Sprintf("%s:%s", c.app.Path, c.app.Path),
+		"-w", workdir,
+		image,
+		gocmd.Name(),
+	)
+	command = append(command, args...)
+
+	return cmdexec.Exec(ctx, command, cmdexec.IncludeStdLogsToError())
+}
+
+// dockerBuild 在工具鏈鏡像的容器中構建位於 path 的主包，二進製文件被寫入容器數據目錄。
+func (c *Chain) dockerBuild(ctx context.Context, binary, path string, flags []string) error {
+	binDir, err := c.dockerBinDir()
+	if err != nil {
+		return err
+	}
+
+	args := []string{gocmd.CommandBuild, gocmd.FlagOut, filepath.Join(binDir, binary)}
+	args = append(args, flags...)
+	args = append(args, ".")
+
+	return c.dockerGo(ctx, path, args...)
+}
+
+// containerOption 返回在容器中運行主目錄為 home 的節點命令的選項。
+func (c *Chain) containerOption(ctx context.Context, home string) (chaincmd.Option, error) {
+	image, err := c.toolchainImage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	binDir, err := c.dockerBinDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return chaincmd.WithContainer(chaincmd.Container{
+		Runtime: dockerRuntime,
+		Image:   image,
+		Name:    c.containerName(home),
+		User:    dockerUser(),
+		Volumes: []string{home, binDir},
+	}), nil
+}
+
+// containerName 返回運行主目錄為 home 的節點的容器名稱。
+func (c *Chain) containerName(home string) string {
+	name := fmt.Sprintf("ignite-%s-%s", c.app.N(), strings.TrimPrefix(filepath.Base(home), "."))
+
+	// 容器名稱只能包含字母、數字、下劃線、點和連字符
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, name)
+}
+
+// removeNodeContainers 刪除上次服務沒有正常退出時遺留的節點容器。
+func (c *Chain) removeNodeContainers(ctx context.Context, nodes []validatorNode) {
+	for _, n := range nodes {
+		// 容器不存在時命令失敗，可以忽略
+		_ = cmdexec.Exec(ctx, []string{dockerRuntime, "rm", "-f", c.containerName(n.home)})
+	}
+}
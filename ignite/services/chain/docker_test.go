@@ -0,0 +1,22 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTarget(t *testing.T) {
+	require.NoError(t, (&Chain{}).checkTarget())
+	require.NoError(t, (&Chain{target: TargetLocal}).checkTarget())
+	require.Error(t, (&Chain{target: "k8s"}).checkTarget())
+	require.Error(t, (&Chain{target: TargetDocker, cosmovisor: true}).checkTarget())
+}
+
+func TestContainerName(t *testing.T) {
+	c := &Chain{app: App{Name: "mars-app"}}
+
+	require.Equal(t, "ignite-marsapp-marsapp", c.containerName("/home/user/.marsapp"))
+	require.Equal(t, "ignite-marsapp-marsapp-bob", c.containerName(validatorNodeHome("/home/user/.marsapp", "Bob")))
+	require.Equal(t, "ignite-marsapp-marsapp-bob-s-node", c.containerName(validatorNodeHome("/home/user/.marsapp", "bob's node")))
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosclient"
 	"github.com/ignite-hq/cli/ignite/pkg/cosmosfaucet"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
 	"github.com/ignite-hq/cli/ignite/pkg/xurl"
 )
 
@@ -22,10 +29,39 @@ var (
 	ErrFaucetAccountDoesNotExist = errors.New("指定的帳戶（faucet.name）不存在")
 )
 
+// FaucetAccountDoesNotExistError 當 config.yml 中水龍頭帳戶池或補充使用的帳戶不存在時返回。
+// errors.Is(err, ErrFaucetAccountDoesNotExist) 對它成立。
+type FaucetAccountDoesNotExistError struct {
+	// Field 是指定帳戶的配置字段，例如 faucet.accounts。
+	Field string
+
+	// Name 是帳戶的名稱。
+	Name string
+}
+
+func (e *FaucetAccountDoesNotExistError) Error() string {
+	return fmt.Sprintf("指定的帳戶（%s）%q 不存在", e.Field, e.Name)
+}
+
+// Is 使 FaucetAccountDoesNotExistError 與 ErrFaucetAccountDoesNotExist 匹配。
+func (e *FaucetAccountDoesNotExistError) Is(target error) bool {
+	return target == ErrFaucetAccountDoesNotExist
+}
+
+// faucetEventTypes 將水龍頭的事件對應到 serve 的生命週期事件。
+var faucetEventTypes = map[cosmosfaucet.EventType]ServeEventType{
+	cosmosfaucet.EventLowBalance:   ServeEventFaucetLowBalance,
+	cosmosfaucet.EventRefilled:     ServeEventFaucetRefilled,
+	cosmosfaucet.EventRefillFailed: ServeEventFaucetRefillFailed,
+}
+
 var (
 	envAPIAddress = os.Getenv("API_ADDRESS")
 )
 
+// faucetLedgerFile 是鏈主目錄中保存水龍頭轉賬賬本的文件。
+const faucetLedgerFile = "faucet-ledger.db"
+
 // Faucet 返回鏈的水龍頭，如果水龍頭返回錯誤
 // 配置錯誤或根本未配置（未啟用）。
 func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
@@ -49,7 +85,8 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		return cosmosfaucet.Faucet{}, ErrFaucetIsNotEnabled
 	}
 
-	if _, err := commands.ShowAccount(ctx, *conf.Faucet.Name); err != nil {
+	faucetAccount, err := commands.ShowAccount(ctx, *conf.Faucet.Name)
+	if err != nil {
 		if err == chaincmdrunner.ErrAccountDoesNotExist {
 			return cosmosfaucet.Faucet{}, ErrFaucetAccountDoesNotExist
 		}
@@ -106,6 +143,163 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
 	}
 
+	// 按客戶端 IP 限制請求並要求完成挑戰，防止通過生成新地址耗盡水龍頭。
+	if limit := conf.Faucet.IPLimit; limit.Window != "" {
+		window, err := time.ParseDuration(limit.Window)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, limit.Window)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.IPRateLimit(limit.Requests, limit.SubnetRequests, window))
+	}
+	if conf.Faucet.IPLimit.TrustProxy {
+		faucetOptions = append(faucetOptions, cosmosfaucet.TrustProxy())
+	}
+	if conf.Faucet.Challenge.ProofOfWork > 0 {
+		faucetOptions = append(faucetOptions, cosmosfaucet.ProofOfWork(conf.Faucet.Challenge.ProofOfWork))
+	}
+	if captcha := conf.Faucet.Challenge.Captcha; captcha.VerifyURL != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.Captcha(
+			cosmosfaucet.NewSiteVerifyCaptcha(captcha.VerifyURL, captcha.Secret),
+			captcha.SiteKey,
+		))
+	}
+
+	// 轉賬記錄在鏈主目錄的賬本中，鏈的狀態被重置時賬本隨之清空。
+	home, err := c.Home()
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+	ledgerStorage, err := cache.NewStorage(filepath.Join(home, faucetLedgerFile))
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+	faucetOptions = append(faucetOptions, cosmosfaucet.LedgerStorage(ledgerStorage))
+	if conf.Faucet.Reconcile {
+		faucetOptions = append(faucetOptions, cosmosfaucet.Reconcile())
+	}
+	if conf.Faucet.AdminToken != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.AdminToken(conf.Faucet.AdminToken))
+	}
+
+	// 轉賬輪流從帳戶池中的帳戶發送，帳戶必須存在於密鑰環中。
+	for _, account := range conf.Faucet.Accounts {
+		if _, err := commands.ShowAccount(ctx, account); err != nil {
+			if err == chaincmdrunner.ErrAccountDoesNotExist {
+				return cosmosfaucet.Faucet{}, &FaucetAccountDoesNotExistError{"faucet.accounts", account}
+			}
+			return cosmosfaucet.Faucet{}, err
+		}
+	}
+	if len(conf.Faucet.Accounts) > 0 {
+		faucetOptions = append(faucetOptions, cosmosfaucet.PoolAccounts(conf.Faucet.Accounts...))
+	}
+
+	// 帳戶餘額低於下限時發出警告，並在配置了金庫帳戶時為其補充代幣。
+	if len(conf.Faucet.LowBalance) > 0 {
+		lowBalance, err := sdk.ParseCoinsNormalized(strings.Join(conf.Faucet.LowBalance, ","))
+		if err != nil {
+			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, strings.Join(conf.Faucet.LowBalance, ","))
+		}
+		faucetOptions = append(faucetOptions,
+			cosmosfaucet.LowBalance(lowBalance),
+			cosmosfaucet.Events(c.faucetEvent),
+		)
+
+		if interval := conf.Faucet.BalanceCheckInterval; interval != "" {
+			balanceCheckInterval, err := time.ParseDuration(interval)
+			if err != nil {
+				return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, interval)
+			}
+			faucetOptions = append(faucetOptions, cosmosfaucet.BalanceCheckInterval(balanceCheckInterval))
+		}
+	}
+	if refill := conf.Faucet.Refill; refill.From != "" {
+		if _, err := commands.ShowAccount(ctx, refill.From); err != nil {
+			if err == chaincmdrunner.ErrAccountDoesNotExist {
+				return cosmosfaucet.Faucet{}, &FaucetAccountDoesNotExistError{"faucet.refill.from", refill.From}
+			}
+			return cosmosfaucet.Faucet{}, err
+		}
+		refillCoins, err := sdk.ParseCoinsNormalized(strings.Join(refill.Coins, ","))
+		if err != nil {
+			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, strings.Join(refill.Coins, ","))
+		}
+		faucetOptions = append(faucetOptions, cosmosfaucet.Refill(refill.From, refillCoins))
+	}
+
+	// 使用 test 密鑰環後端時在進程內簽名轉賬，同一區塊內的請求合併到一個交易中發送。
+	// 其他後端需要輸入密碼，仍然通過鏈的二進製文件轉賬。
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+	if backend == chaincmd.KeyringBackendTest {
+		signer, err := c.faucetSigner(ctx, id, faucetAccount.Address)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, err
+		}
+		faucetOptions = append(faucetOptions, cosmosfaucet.NativeSigner(signer))
+	}
+
 	// 使用選項初始化水龍頭並返回。
 	return cosmosfaucet.New(ctx, commands, faucetOptions...)
 }
+
+// faucetEvent 打印水龍頭帳戶餘額不足和補充的警告，並將其作為 serve 事件發出。
+func (c *Chain) faucetEvent(e cosmosfaucet.Event) {
+	switch e.Type {
+	case cosmosfaucet.EventLowBalance:
+		fmt.Fprintf(c.stdLog().out, "⚠️  水龍頭帳戶 %s 餘額不足: %s\n", e.Account, e.Balance)
+	case cosmosfaucet.EventRefilled:
+		fmt.Fprintf(c.stdLog().out, "💧 水龍頭帳戶 %s 已補充 %s\n", e.Account, e.Coins)
+	case cosmosfaucet.EventRefillFailed:
+		fmt.Fprintf(c.stdLog().out, "⚠️  無法補充水龍頭帳戶 %s: %s\n", e.Account, e.Err)
+	}
+
+	payload := map[string]interface{}{
+		"account": e.Account,
+		"address": e.Address,
+		"balance": e.Balance.String(),
+	}
+	if !e.Coins.Empty() {
+		payload["coins"] = e.Coins.String()
+	}
+	if e.Err != nil {
+		payload["error"] = e.Err.Error()
+	}
+	c.emit(faucetEventTypes[e.Type], payload)
+}
+
+// faucetSigner 返回在進程內簽名水龍頭交易的客戶端，address 是水龍頭帳戶的地址。
+// 客戶端在水龍頭發送第一筆轉賬時才連接節點，因此可以在節點啟動前創建。
+func (c *Chain) faucetSigner(ctx context.Context, chainID, address string) (cosmosclient.Client, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	rpcAddress, err := xurl.HTTP(conf.Host.RPC)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("無效的主機 rpc 地址格式: %w", err)
+	}
+
+	prefix, err := cosmosutil.GetAddressPrefix(address)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	return cosmosclient.New(
+		ctx,
+		cosmosclient.WithChainID(chainID),
+		cosmosclient.WithHome(home),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringTest),
+		cosmosclient.WithNodeAddress(rpcAddress),
+		cosmosclient.WithAddressPrefix(prefix),
+	)
+}
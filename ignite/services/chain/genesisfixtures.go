@@ -0,0 +1,379 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite-hq/cli/ignite/pkg/protoanalysis"
+)
+
+// genesisStateMessage 是模塊創世 proto 消息的名稱。
+const genesisStateMessage = "GenesisState"
+
+// genesisFixtureExts 是創世數據文件支持的擴展名。
+var genesisFixtureExts = map[string]bool{
+	".json": true,
+	".yml":  true,
+	".yaml": true,
+}
+
+// applyGenesisFixtures 將 config.yml 中的創世數據根據模塊的創世 proto 驗證後，
+// 合併到主節點創世的 app_state 中。addresses 保存帳戶名稱對應的地址，用於數據中的模板。
+func (c *Chain) applyGenesisFixtures(ctx context.Context, conf chainconfig.Config, addresses map[string]string) error {
+	if len(conf.GenesisFixtures) == 0 {
+		return nil
+	}
+
+	fixtures, err := loadGenesisFixtures(c.app.Path, conf.GenesisFixtures, addresses)
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	var fields protoanalysis.MessageFields
+	protoPath := filepath.Join(c.app.Path, conf.Build.Proto.Path)
+	if _, err := os.Stat(protoPath); err == nil {
+		if fields, err = protoanalysis.ParseMessageFields(ctx, protoPath); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	modules := make([]string, 0, len(fixtures))
+	for module := range fixtures {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		if err := validateGenesisFixture(fields, module, fixtures[module]); err != nil {
+			return &CannotBuildAppError{err}
+		}
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+	if err := mergeGenesisFixtures(genesisPath, fixtures); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🌱 創世數據已合併到模塊: %s\n", strings.Join(modules, ", "))
+	return nil
+}
+
+// loadGenesisFixtures 讀取相對於 root 的創世數據文件和目錄，返回按模塊名合併的模塊狀態。
+// 文件的頂層鍵是模塊名。目錄中名為 <module>.json、<module>.yml 或 <module>.yaml 的文件保存模塊的狀態，
+// 名為 <module> 的子目錄中的每個文件保存模塊狀態的一部分。
+// 同一模塊的多個部分深度合併，其中的列表會被連接。
+// 文件內容是 Go 模板，可以使用 {{ address "alice" }} 引用 addresses 中帳戶的地址。
+func loadGenesisFixtures(root string, paths []string, addresses map[string]string) (map[string]interface{}, error) {
+	fixtures := make(map[string]interface{})
+
+	add := func(module string, state interface{}) {
+		fixtures[module] = mergeFixtureParts(fixtures[module], state)
+	}
+
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			data, err := readGenesisFixture(path, addresses)
+			if err != nil {
+				return nil, err
+			}
+			modules, ok := data.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("創世數據文件 %s 的頂層必須是以模塊名為鍵的對象", path)
+			}
+			for module, state := range modules {
+				add(module, state)
+			}
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+
+			if !entry.IsDir() {
+				ext := filepath.Ext(entry.Name())
+				if !genesisFixtureExts[ext] {
+					continue
+				}
+				state, err := readGenesisFixture(entryPath, addresses)
+				if err != nil {
+					return nil, err
+				}
+				add(strings.TrimSuffix(entry.Name(), ext), state)
+				continue
+			}
+
+			parts, err := os.ReadDir(entryPath)
+			if err != nil {
+				return nil, err
+			}
+			for _, part := range parts {
+				if part.IsDir() || !genesisFixtureExts[filepath.Ext(part.Name())] {
+					continue
+				}
+				state, err := readGenesisFixture(filepath.Join(entryPath, part.Name()), addresses)
+				if err != nil {
+					return nil, err
+				}
+				add(entry.Name(), state)
+			}
+		}
+	}
+
+	return fixtures, nil
+}
+
+// readGenesisFixture 執行 path 中創世數據文件的模板，並將 JSON 或 YAML 內容解碼為 JSON 值。
+// 數字保存為 json.Number 以免丟失精度。
+func readGenesisFixture(path string, addresses map[string]string) (interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"address": func(name string) (string, error) {
+				address, ok := addresses[name]
+				if !ok {
+					return "", fmt.Errorf("accounts 中沒有名為 %q 的帳戶", name)
+				}
+				return address, nil
+			},
+		}).
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("創世數據文件 %s: %w", path, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, nil); err != nil {
+		return nil, fmt.Errorf("創世數據文件 %s: %w", path, err)
+	}
+
+	data, err := yaml.YAMLToJSON(rendered.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("創世數據文件 %s: %w", path, err)
+	}
+
+	var value interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return nil, fmt.Errorf("創世數據文件 %s: %w", path, err)
+	}
+
+	return value, nil
+}
+
+// mergeFixtureParts 深度合併同一模塊的兩部分創世數據，列表會被連接，其他值以 src 為準。
+func mergeFixtureParts(dst, src interface{}) interface{} {
+	switch src := src.(type) {
+	case map[string]interface{}:
+		dstMap, ok := dst.(map[string]interface{})
+		if !ok {
+			return src
+		}
+		for key, value := range src {
+			dstMap[key] = mergeFixtureParts(dstMap[key], value)
+		}
+		return dstMap
+	case []interface{}:
+		if dstList, ok := dst.([]interface{}); ok {
+			return append(dstList, src...)
+		}
+		return src
+	default:
+		return src
+	}
+}
+
+// mergeGenesisState 將創世數據深度合併到創世中已有的模塊狀態，列表和其他值會被替換。
+func mergeGenesisState(dst, src interface{}) interface{} {
+	srcMap, ok := src.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	dstMap, ok := dst.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	for key, value := range srcMap {
+		dstMap[key] = mergeGenesisState(dstMap[key], value)
+	}
+	return dstMap
+}
+
+// mergeGenesisFixtures 將按模塊名保存的創世數據合併到位於 genesisPath 的創世的 app_state 中。
+func mergeGenesisFixtures(genesisPath string, fixtures map[string]interface{}) error {
+	return cosmosutil.UpdateGenesisMap(genesisPath, func(genesis map[string]interface{}) error {
+		appState, ok := genesis["app_state"].(map[string]interface{})
+		if !ok {
+			appState = make(map[string]interface{})
+			genesis["app_state"] = appState
+		}
+		for module, state := range fixtures {
+			appState[module] = mergeGenesisState(appState[module], state)
+		}
+		return nil
+	})
+}
+
+// validateGenesisFixture 根據模塊 proto 中的 GenesisState 消息驗證模塊的創世數據，
+// 報告未知的字段和類型錯誤的值。找不到模塊的 GenesisState 時（例如 SDK 模塊）不驗證。
+func validateGenesisFixture(fields protoanalysis.MessageFields, module string, state interface{}) error {
+	genesis, ok := moduleGenesisState(fields, module)
+	if !ok {
+		return nil
+	}
+	return validateFixtureMessage(fields, genesis, state, "app_state."+module)
+}
+
+// moduleGenesisState 返回模塊的 GenesisState 消息的全名，
+// 模塊的 proto 包名的最後一段是模塊名，例如 username.mars.mars。
+func moduleGenesisState(fields protoanalysis.MessageFields, module string) (string, bool) {
+	var names []string
+	for name := range fields {
+		pkg := strings.TrimSuffix(name, "."+genesisStateMessage)
+		if pkg == name {
+			continue
+		}
+		if pkg == module || strings.HasSuffix(pkg, "."+module) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+func validateFixtureMessage(fields protoanalysis.MessageFields, message string, value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("創世數據無效 %s: 應為 %s 對象", path, message)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field, ok := findFixtureField(fields[message], key)
+		if !ok {
+			return fmt.Errorf("創世數據無效 %s: %s 沒有字段 %q", path, message, key)
+		}
+
+		fieldPath := path + "." + key
+		value := obj[key]
+
+		switch {
+		case value == nil:
+		case field.Map:
+			entries, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("創世數據無效 %s: 應為對象", fieldPath)
+			}
+			for k, v := range entries {
+				if err := validateFixtureValue(fields, message, field.Type, v, fmt.Sprintf("%s[%q]", fieldPath, k)); err != nil {
+					return err
+				}
+			}
+		case field.Repeated:
+			list, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("創世數據無效 %s: 應為列表", fieldPath)
+			}
+			for i, v := range list {
+				if err := validateFixtureValue(fields, message, field.Type, v, fmt.Sprintf("%s[%d]", fieldPath, i)); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := validateFixtureValue(fields, message, field.Type, value, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateFixtureValue(fields protoanalysis.MessageFields, message, typ string, value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+
+	if name, ok := fields.Resolve(message, typ); ok {
+		return validateFixtureMessage(fields, name, value, path)
+	}
+
+	// proto 的 JSON 編碼接受以字符串表示的數字
+	var valid bool
+	switch typ {
+	case "string", "bytes":
+		_, valid = value.(string)
+	case "bool":
+		_, valid = value.(bool)
+	case "double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+		"fixed32", "fixed64", "sfixed32", "sfixed64":
+		switch value.(type) {
+		case json.Number, float64, string:
+			valid = true
+		}
+	default:
+		// 枚舉和其他包中的類型不驗證
+		valid = true
+	}
+	if !valid {
+		return fmt.Errorf("創世數據無效 %s: 應為 %s", path, typ)
+	}
+
+	return nil
+}
+
+// findFixtureField 按 proto 中的名稱或 JSON 名稱查找字段。
+func findFixtureField(fields []protoanalysis.Field, key string) (protoanalysis.Field, bool) {
+	for _, field := range fields {
+		if field.Name == key || field.JSONName() == key {
+			return field, true
+		}
+	}
+	return protoanalysis.Field{}, false
+}
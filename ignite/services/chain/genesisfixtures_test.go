@@ -0,0 +1,182 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/protoanalysis"
+)
+
+const genesisFixtureProto = `syntax = "proto3";
+package username.mars.mars;
+
+message Post {
+  string creator = 1;
+  string title = 2;
+  uint64 id = 3;
+}
+
+message Params {
+  bool enabled = 1;
+}
+
+message GenesisState {
+  Params params = 1;
+  repeated Post postList = 2;
+  uint64 postCount = 3;
+  map<string, Post> post_index = 4;
+}
+`
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestLoadGenesisFixtures(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"fixtures/demo.yml": `
+mars:
+  postList:
+    - creator: '{{ address "alice" }}'
+      title: first
+bank:
+  denom_metadata: []
+`,
+		"fixtures/modules/mars/posts.json": `{"postList": [{"title": "second", "id": 1}], "postCount": 2}`,
+		"fixtures/modules/mars/params.yml": `params: {enabled: true}`,
+		"fixtures/modules/mars/README.md":  `ignored`,
+		"fixtures/modules/staking.yaml":    `params: {max_validators: 10}`,
+	})
+
+	fixtures, err := loadGenesisFixtures(root, []string{"fixtures/demo.yml", "fixtures/modules"}, map[string]string{
+		"alice": "cosmos1alice",
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(fixtures)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"bank": {"denom_metadata": []},
+		"mars": {
+			"params": {"enabled": true},
+			"postCount": 2,
+			"postList": [
+				{"creator": "cosmos1alice", "title": "first"},
+				{"title": "second", "id": 1}
+			]
+		},
+		"staking": {"params": {"max_validators": 10}}
+	}`, string(data))
+
+	writeFiles(t, root, map[string]string{
+		"fixtures/unknown.yml": `mars: {creator: '{{ address "bob" }}'}`,
+	})
+	_, err = loadGenesisFixtures(root, []string{"fixtures/unknown.yml"}, nil)
+	require.ErrorContains(t, err, `accounts 中沒有名為 "bob" 的帳戶`)
+}
+
+func TestValidateGenesisFixture(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"proto/mars/genesis.proto": genesisFixtureProto})
+
+	fields, err := protoanalysis.ParseMessageFields(context.Background(), filepath.Join(root, "proto"))
+	require.NoError(t, err)
+
+	decode := func(s string) interface{} {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+
+	cases := []struct {
+		name, module, state, err string
+	}{
+		{
+			name:   "valid",
+			module: "mars",
+			state:  `{"params": {"enabled": true}, "postList": [{"title": "a", "id": "1"}], "postCount": 1, "post_index": {"a": {"title": "a"}}}`,
+		},
+		{
+			name:   "json name",
+			module: "mars",
+			state:  `{"postIndex": {}}`,
+		},
+		{
+			name:   "unknown field",
+			module: "mars",
+			state:  `{"postList": [{"name": "a"}]}`,
+			err:    `創世數據無效 app_state.mars.postList[0]: username.mars.mars.Post 沒有字段 "name"`,
+		},
+		{
+			name:   "not a list",
+			module: "mars",
+			state:  `{"postList": {"title": "a"}}`,
+			err:    "創世數據無效 app_state.mars.postList: 應為列表",
+		},
+		{
+			name:   "wrong scalar",
+			module: "mars",
+			state:  `{"params": {"enabled": "yes"}}`,
+			err:    "創世數據無效 app_state.mars.params.enabled: 應為 bool",
+		},
+		{
+			name:   "module without proto",
+			module: "bank",
+			state:  `{"anything": true}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGenesisFixture(fields, tt.module, decode(tt.state))
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMergeGenesisFixtures(t *testing.T) {
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	writeFiles(t, filepath.Dir(genesisPath), map[string]string{
+		"genesis.json": `{
+			"chain_id": "mars",
+			"app_state": {
+				"bank": {"supply": [], "params": {"default_send_enabled": true}},
+				"mars": {"params": {}, "postList": [{"title": "default"}], "postCount": "18446744073709551615"}
+			}
+		}`,
+	})
+
+	require.NoError(t, mergeGenesisFixtures(genesisPath, map[string]interface{}{
+		"mars": map[string]interface{}{
+			"postList":  []interface{}{map[string]interface{}{"title": "fixture"}},
+			"postCount": json.Number("1"),
+		},
+		"bank": map[string]interface{}{
+			"params": map[string]interface{}{"default_send_enabled": false},
+		},
+	}))
+
+	data, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"chain_id": "mars",
+		"app_state": {
+			"bank": {"supply": [], "params": {"default_send_enabled": false}},
+			"mars": {"params": {}, "postList": [{"title": "fixture"}], "postCount": 1}
+		}
+	}`, string(data))
+}
@@ -0,0 +1,258 @@
+package chain
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosfaucet"
+	"github.com/ignite-hq/cli/ignite/pkg/httpstatuschecker"
+	"github.com/ignite-hq/cli/ignite/pkg/tendermintrpc"
+	"github.com/ignite-hq/cli/ignite/pkg/xhttp"
+	"github.com/ignite-hq/cli/ignite/pkg/xurl"
+)
+
+// healthCheckTimeout 是一次健康檢查等待節點、API 和 gRPC 響應的最長時間。
+const healthCheckTimeout = 3 * time.Second
+
+// ServePhase 是 serve 的當前階段。
+type ServePhase string
+
+const (
+	// ServePhasePending 表示 serve 尚未開始構建。
+	ServePhasePending ServePhase = "pending"
+
+	// ServePhaseBuilding 表示正在構建鏈。
+	ServePhaseBuilding ServePhase = "building"
+
+	// ServePhaseStarting 表示鏈已構建，正在初始化或啟動節點。
+	ServePhaseStarting ServePhase = "starting"
+
+	// ServePhaseRunning 表示節點已啟動。
+	ServePhaseRunning ServePhase = "running"
+
+	// ServePhaseFailed 表示構建或啟動失敗，serve 在等待源代碼被修復。
+	ServePhaseFailed ServePhase = "failed"
+)
+
+// Health 是狀態端點返回的鏈的健康狀況。
+type Health struct {
+	// Ready 表示節點已啟動、在出塊且沒有在同步，API 和 gRPC 可以訪問。
+	Ready bool `json:"ready"`
+
+	// Phase 是 serve 的當前階段。
+	Phase ServePhase `json:"phase"`
+
+	// Error 是構建或啟動失敗的原因。
+	Error string `json:"error,omitempty"`
+
+	Node   NodeHealth     `json:"node"`
+	API    EndpointHealth `json:"api"`
+	GRPC   EndpointHealth `json:"grpc"`
+	Faucet *FaucetHealth  `json:"faucet,omitempty"`
+}
+
+// NodeHealth 是節點的健康狀況。
+type NodeHealth struct {
+	Address    string `json:"address"`
+	Reachable  bool   `json:"reachable"`
+	Height     int64  `json:"height"`
+	CatchingUp bool   `json:"catching_up"`
+}
+
+// EndpointHealth 是節點的一個服務端點的健康狀況。
+type EndpointHealth struct {
+	Address   string `json:"address"`
+	Reachable bool   `json:"reachable"`
+}
+
+// FaucetHealth 是水龍頭帳戶的健康狀況。
+type FaucetHealth struct {
+	Account string    `json:"account"`
+	Address string    `json:"address"`
+	Balance sdk.Coins `json:"balance"`
+	Error   string    `json:"error,omitempty"`
+
+	// Accounts 是水龍頭帳戶池中其他帳戶（faucet.accounts）的健康狀況。
+	Accounts []FaucetHealth `json:"accounts,omitempty"`
+}
+
+// serveStatus 根據 serve 的生命週期事件記錄 serve 的當前階段。
+type serveStatus struct {
+	mu    sync.RWMutex
+	phase ServePhase
+	err   string
+
+	// faucetAccounts 是水龍頭啟動後使用的帳戶，第一個是 faucet.name 的帳戶。
+	faucetAccounts []chaincmdrunner.Account
+}
+
+// observe 根據生命週期事件更新 serve 的階段。
+func (s *serveStatus) observe(typ ServeEventType, payload map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch typ {
+	case ServeEventBuildStarted:
+		s.phase, s.err = ServePhaseBuilding, ""
+		s.faucetAccounts = nil
+	case ServeEventBuildFinished:
+		s.phase = ServePhaseStarting
+	case ServeEventNodeStarted:
+		s.phase = ServePhaseRunning
+	case ServeEventBuildFailed, ServeEventStartFailed:
+		s.phase = ServePhaseFailed
+		if err, ok := payload["error"].(string); ok {
+			s.err = err
+		}
+	}
+}
+
+// setFaucet 記錄水龍頭使用的帳戶，第一個是 faucet.name 的帳戶。
+func (s *serveStatus) setFaucet(accounts ...chaincmdrunner.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faucetAccounts = accounts
+}
+
+func (s *serveStatus) get() (phase ServePhase, err string, faucetAccounts []chaincmdrunner.Account) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.phase == "" {
+		return ServePhasePending, s.err, s.faucetAccounts
+	}
+	return s.phase, s.err, s.faucetAccounts
+}
+
+// Health 檢查 serve 的階段以及節點、API、gRPC 和水龍頭的健康狀況。
+func (c *Chain) Health(ctx context.Context) (Health, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return Health{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	phase, phaseErr, faucetAccounts := c.status.get()
+	h := Health{
+		Phase: phase,
+		Error: phaseErr,
+	}
+
+	// 注意：地址格式錯誤在啟動節點時報告，這裡只影響可訪問性
+	rpcAddr, _ := xurl.HTTP(conf.Host.RPC)
+	apiAddr, _ := xurl.HTTP(conf.Host.API)
+
+	h.Node.Address = rpcAddr
+	if info, err := tendermintrpc.New(rpcAddr).Status(ctx); err == nil {
+		h.Node.Reachable = true
+		h.Node.Height = info.LatestBlockHeight
+		h.Node.CatchingUp = info.CatchingUp
+	}
+
+	h.API.Address = apiAddr
+	h.API.Reachable, _ = httpstatuschecker.Check(ctx, apiAddr+"/cosmos/base/tendermint/v1beta1/node_info")
+
+	h.GRPC.Address = xurl.Address(conf.Host.GRPC)
+	h.GRPC.Reachable = isTCPReachable(ctx, h.GRPC.Address)
+
+	if len(faucetAccounts) > 0 {
+		faucet := faucetHealth(ctx, apiAddr, faucetAccounts[0])
+		for _, account := range faucetAccounts[1:] {
+			faucet.Accounts = append(faucet.Accounts, faucetHealth(ctx, apiAddr, account))
+		}
+		h.Faucet = &faucet
+	}
+
+	h.Ready = h.Phase == ServePhaseRunning &&
+		h.Node.Reachable &&
+		h.Node.Height > 0 &&
+		!h.Node.CatchingUp &&
+		h.API.Reachable &&
+		h.GRPC.Reachable
+
+	return h, nil
+}
+
+// faucetHealth 通過位於 apiAddr 的 API 查詢水龍頭帳戶的餘額。
+func faucetHealth(ctx context.Context, apiAddr string, account chaincmdrunner.Account) FaucetHealth {
+	h := FaucetHealth{
+		Account: account.Name,
+		Address: account.Address,
+	}
+	balance, err := cosmosfaucet.QueryBalance(ctx, apiAddr, account.Address)
+	if err != nil {
+		h.Error = err.Error()
+	}
+	h.Balance = balance
+	return h
+}
+
+// isTCPReachable 檢查是否可以連接到 address。
+func isTCPReachable(ctx context.Context, address string) bool {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// healthHandler 返回狀態端點的處理程序。
+// "/" 總是返回健康狀況，"/ready" 在鏈未就緒時返回 503，可以用於等待鏈就緒。
+func (c *Chain) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	health := func(w http.ResponseWriter, r *http.Request, status func(Health) int) {
+		h, err := c.Health(r.Context())
+		if err != nil {
+			xhttp.ResponseJSON(w, http.StatusInternalServerError, xhttp.NewErrorResponse(err))
+			return
+		}
+		xhttp.ResponseJSON(w, status(h), h)
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		health(w, r, func(Health) int { return http.StatusOK })
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		health(w, r, func(h Health) int {
+			if h.Ready {
+				return http.StatusOK
+			}
+			return http.StatusServiceUnavailable
+		})
+	})
+
+	return mux
+}
+
+// runHealthServer 在 host 上啟動狀態端點。
+func (c *Chain) runHealthServer(ctx context.Context, host string) error {
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    host,
+		Handler: c.healthHandler(),
+	})
+}
+
+// healthHost 返回配置的狀態端點地址，配置無效時返回空字符串，serve 會報告配置錯誤。
+func (c *Chain) healthHost() string {
+	conf, err := c.Config()
+	if err != nil {
+		return ""
+	}
+	return conf.Host.Health
+}
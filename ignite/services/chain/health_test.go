@@ -0,0 +1,125 @@
+package chain
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+)
+
+func TestServeStatus(t *testing.T) {
+	var s serveStatus
+
+	phase, _, _ := s.get()
+	require.Equal(t, ServePhasePending, phase)
+
+	s.observe(ServeEventBuildStarted, nil)
+	phase, _, _ = s.get()
+	require.Equal(t, ServePhaseBuilding, phase)
+
+	s.observe(ServeEventBuildFailed, map[string]interface{}{"error": "syntax error"})
+	phase, err, _ := s.get()
+	require.Equal(t, ServePhaseFailed, phase)
+	require.Equal(t, "syntax error", err)
+
+	s.observe(ServeEventBuildStarted, nil)
+	s.observe(ServeEventBuildFinished, nil)
+	phase, err, _ = s.get()
+	require.Equal(t, ServePhaseStarting, phase)
+	require.Empty(t, err)
+
+	s.observe(ServeEventNodeStarted, nil)
+	s.setFaucet(chaincmdrunner.Account{Name: "faucet", Address: "cosmos1faucet"})
+	phase, _, accounts := s.get()
+	require.Equal(t, ServePhaseRunning, phase)
+	require.Equal(t, []chaincmdrunner.Account{{Name: "faucet", Address: "cosmos1faucet"}}, accounts)
+
+	// the faucet accounts are cleared on rebuild.
+	s.observe(ServeEventBuildStarted, nil)
+	_, _, accounts = s.get()
+	require.Empty(t, accounts)
+}
+
+func TestHealth(t *testing.T) {
+	var catchingUp bool
+
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if catchingUp {
+			w.Write([]byte(`{"result":{"node_info":{"network":"mars"},"sync_info":{"latest_block_height":"7","catching_up":true}}}`))
+			return
+		}
+		w.Write([]byte(`{"result":{"node_info":{"network":"mars"},"sync_info":{"latest_block_height":"7","catching_up":false}}}`))
+	}))
+	defer rpc.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cosmos/bank/v1beta1/balances/") {
+			w.Write([]byte(`{"balances":[{"denom":"token","amount":"42"}]}`))
+		}
+	}))
+	defer api.Close()
+
+	grpc, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer grpc.Close()
+
+	c := &Chain{
+		app:    App{Path: t.TempDir()},
+		status: &serveStatus{},
+		options: chainOptions{
+			configOverride: func(conf *chainconfig.Config) {
+				conf.Host.RPC = rpc.URL
+				conf.Host.API = api.URL
+				conf.Host.GRPC = grpc.Addr().String()
+			},
+		},
+	}
+
+	h, err := c.Health(context.Background())
+	require.NoError(t, err)
+	require.False(t, h.Ready)
+	require.Equal(t, ServePhasePending, h.Phase)
+	require.True(t, h.Node.Reachable)
+	require.Equal(t, int64(7), h.Node.Height)
+	require.True(t, h.API.Reachable)
+	require.True(t, h.GRPC.Reachable)
+	require.Nil(t, h.Faucet)
+
+	c.emit(ServeEventNodeStarted, nil)
+	c.status.setFaucet(
+		chaincmdrunner.Account{Name: "faucet", Address: "cosmos1faucet"},
+		chaincmdrunner.Account{Name: "faucet2", Address: "cosmos1faucet2"},
+	)
+
+	h, err = c.Health(context.Background())
+	require.NoError(t, err)
+	require.True(t, h.Ready)
+	require.Equal(t, &FaucetHealth{
+		Account: "faucet",
+		Address: "cosmos1faucet",
+		Balance: sdk.NewCoins(sdk.NewInt64Coin("token", 42)),
+		Accounts: []FaucetHealth{{
+			Account: "faucet2",
+			Address: "cosmos1faucet2",
+			Balance: sdk.NewCoins(sdk.NewInt64Coin("token", 42)),
+		}},
+	}, h.Faucet)
+
+	// 節點在同步時沒有就緒
+	catchingUp = true
+	rec := httptest.NewRecorder()
+	c.healthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	c.healthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/imdario/mergo"
 
@@ -78,45 +79,37 @@ func (c *Chain) InitChain(ctx context.Context) error {
 		conf.Genesis["chain_id"] = chainID
 	}
 
-	// 初始化應用配置
-	genesisPath, err := c.GenesisPath()
-	if err != nil {
-		return err
-	}
-	appTOMLPath, err := c.AppTOMLPath()
-	if err != nil {
-		return err
-	}
-	clientTOMLPath, err := c.ClientTOMLPath()
-	if err != nil {
+	if err := overrideConfigs(home, conf); err != nil {
 		return err
 	}
-	configTOMLPath, err := c.ConfigTOMLPath()
-	if err != nil {
+
+	// 初始化其他驗證者節點。
+	return c.initValidatorNodes(ctx, conf)
+}
+
+// overrideConfigs 將 config.yml 中的創世和 SDK 配置覆蓋應用到位於 home 的節點。
+func overrideConfigs(home string, conf chainconfig.Config) error {
+	if err := overrideConfig(confile.DefaultJSONEncodingCreator, nodeGenesisPath(home), conf.Genesis); err != nil {
 		return err
 	}
 
+	return overrideTOMLConfigs(home, conf)
+}
+
+// overrideTOMLConfigs 將 config.yml 中的 init.app、init.client 和 init.config 覆蓋
+// 應用到位於 home 的節點，不會修改創世。
+func overrideTOMLConfigs(home string, conf chainconfig.Config) error {
 	appconfigs := []struct {
-		ec      confile.EncodingCreator
 		path    string
 		changes map[string]interface{}
 	}{
-		{confile.DefaultJSONEncodingCreator, genesisPath, conf.Genesis},
-		{confile.DefaultTOMLEncodingCreator, appTOMLPath, conf.Init.App},
-		{confile.DefaultTOMLEncodingCreator, clientTOMLPath, conf.Init.Client},
-		{confile.DefaultTOMLEncodingCreator, configTOMLPath, conf.Init.Config},
+		{nodeAppTOMLPath(home), conf.Init.App},
+		{nodeClientTOMLPath(home), conf.Init.Client},
+		{nodeConfigTOMLPath(home), conf.Init.Config},
 	}
 
 	for _, ac := range appconfigs {
-		cf := confile.New(ac.ec, ac.path)
-		var conf map[string]interface{}
-		if err := cf.Load(&conf); err != nil {
-			return err
-		}
-		if err := mergo.Merge(&conf, ac.changes, mergo.WithOverride); err != nil {
-			return err
-		}
-		if err := cf.Save(conf); err != nil {
+		if err := overrideConfig(confile.DefaultTOMLEncodingCreator, ac.path, ac.changes); err != nil {
 			return err
 		}
 	}
@@ -124,6 +117,18 @@ func (c *Chain) InitChain(ctx context.Context) error {
 	return nil
 }
 
+func overrideConfig(ec confile.EncodingCreator, path string, changes map[string]interface{}) error {
+	cf := confile.New(ec, path)
+	var conf map[string]interface{}
+	if err := cf.Load(&conf); err != nil {
+		return err
+	}
+	if err := mergo.Merge(&conf, changes, mergo.WithOverride); err != nil {
+		return err
+	}
+	return cf.Save(conf)
+}
+
 //InitAccounts 初始化鏈賬戶並創建驗證者 gentxs
 func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error {
 	commands, err := c.Commands(ctx)
@@ -131,8 +136,19 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 		return err
 	}
 
-	// 將賬戶從配置添加到創世
+	// mnemonics 保存帳戶的助記詞，用於在其他驗證者節點中導入密鑰。
+	mnemonics := make(map[string]string)
+
+	// addresses 保存帳戶的地址，用於解析創世數據中引用的帳戶。
+	addresses := make(map[string]string)
+
+	// 將賬戶從配置添加到創世，模塊帳戶在所有帳戶添加後處理
+	now := time.Now()
 	for _, account := range conf.Accounts {
+		if account.Module != "" {
+			continue
+		}
+
 		var generatedAccount chaincmdrunner.Account
 		accountAddress := account.Address
 
@@ -143,10 +159,17 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 				return err
 			}
 			accountAddress = generatedAccount.Address
+			mnemonics[account.Name] = generatedAccount.Mnemonic
 		}
 
+		addresses[account.Name] = accountAddress
+
 		coins := strings.Join(account.Coins, ",")
-		if err := commands.AddGenesisAccount(ctx, accountAddress, coins); err != nil {
+		if account.Vesting != nil {
+			if err := c.addVestingAccount(ctx, commands, account, accountAddress, now); err != nil {
+				return err
+			}
+		} else if err := commands.AddGenesisAccount(ctx, accountAddress, coins); err != nil {
 			return err
 		}
 
@@ -168,6 +191,19 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 		}
 	}
 
+	if err := c.addModuleAccounts(conf, addresses); err != nil {
+		return err
+	}
+
+	// 在生成 gentx 之前合併創世數據，使其他驗證者節點複製的創世包含這些數據
+	if err := c.applyGenesisFixtures(ctx, conf, addresses); err != nil {
+		return err
+	}
+
+	if len(conf.Validators) > 0 {
+		return c.issueValidatorNodesGentxs(ctx, conf, mnemonics)
+	}
+
 	_, err = c.IssueGentx(ctx, Validator{
 		Name:          conf.Validator.Name,
 		StakingAmount: conf.Validator.Staked,
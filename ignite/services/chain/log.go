@@ -2,13 +2,23 @@ package chain
 
 import (
 	"io"
-	"os"
+	"path/filepath"
 	"strings"
 
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite-hq/cli/ignite/pkg/lineprefixer"
+	"github.com/ignite-hq/cli/ignite/pkg/nodelog"
 	"github.com/ignite-hq/cli/ignite/pkg/prefixgen"
 )
 
+const (
+	// nodeLogDir 是節點主目錄中保存節點日誌文件的目錄。
+	nodeLogDir = "log"
+
+	// nodeLogFile 是節點日誌文件的名稱，輪轉後的文件以 .1、.2 等為後綴。
+	nodeLogFile = "node.log"
+)
+
 //prefixes 保存日誌消息的前綴配置。
 var prefixes = map[logType]struct {
 	Name  string
@@ -53,8 +63,8 @@ func (c *Chain) stdLog() std {
 		stderr io.Writer = prefixed(c.stderr)
 	)
 	if c.logLevel == LogRegular {
-		stdout = os.Stdout
-		stderr = os.Stderr
+		stdout = c.out
+		stderr = c.errOut
 	}
 	return std{
 		out: stdout,
@@ -69,3 +79,64 @@ func (c *Chain) genPrefix(logType logType) string {
 		New(prefix.Name, prefixgen.Common(prefixgen.Color(prefix.Color))...).
 		Gen(c.app.Name)
 }
+
+// nodeLogPath 返回主目錄為 home 的節點的日誌文件路徑。
+func nodeLogPath(home string) string {
+	return filepath.Join(home, nodeLogDir, nodeLogFile)
+}
+
+// nodeLogCommands 返回以 JSON 格式輸出日誌的節點運行者，節點的全部輸出寫入其主目錄下的輪轉日誌文件。
+// 在詳細模式或設置了日誌過濾器時，過濾器保留的日誌行會以可讀格式輸出到終端，並高亮 panic 和錯誤。
+// 返回的函數在節點停止後關閉日誌文件。
+func (c *Chain) nodeLogCommands(commands chaincmdrunner.Runner, n validatorNode, primary bool) (chaincmdrunner.Runner, func() error, error) {
+	file, err := nodelog.OpenRotatingFile(nodeLogPath(n.home), nodelog.DefaultMaxSize, nodelog.DefaultMaxBackups)
+	if err != nil {
+		return chaincmdrunner.Runner{}, nil, err
+	}
+
+	var out io.Writer = io.Discard
+	if c.logLevel == LogVerbose || !c.logFilter.IsZero() {
+		out = c.out
+		if c.events != nil {
+			// 標準輸出保留給 JSON 事件
+			out = c.errOut
+		}
+	}
+
+	prefix := c.genPrefix(logAppd)
+	if !primary {
+		prefix = prefixgen.
+			New(prefixes[logAppd].Name, prefixgen.Common(prefixgen.Color(prefixes[logAppd].Color))...).
+			Gen(n.validator.Name)
+	}
+
+	// 標準輸出和標準錯誤各用一個寫入器，避免兩者的不完整行混在一起
+	options := []nodelog.Option{
+		nodelog.WithFilter(c.logFilter),
+		nodelog.WithFile(file),
+		nodelog.WithPrefix(prefix),
+	}
+	stdout := nodelog.NewWriter(out, options...)
+	stderr := nodelog.NewWriter(out, options...)
+
+	args := []string{"--log_format", "json"}
+	if !c.logFilter.IsZero() {
+		args = append(args, "--log_level", c.logFilter.MinLevel().String())
+	}
+
+	commands = commands.Copy(
+		chaincmdrunner.Stdout(stdout),
+		chaincmdrunner.Stderr(stderr),
+		chaincmdrunner.DaemonLogPrefix(""),
+		chaincmdrunner.CLILogPrefix(""),
+		chaincmdrunner.StartArgs(args...),
+	)
+
+	closeLog := func() error {
+		stdout.Flush()
+		stderr.Flush()
+		return file.Close()
+	}
+
+	return commands, closeLog, nil
+}
@@ -78,7 +78,7 @@ func (p *stargatePlugin) appTOML(homePath string, conf chainconfig.Config) error
 	config.Set("grpc.address", conf.Host.GRPC)
 	config.Set("grpc-web.address", conf.Host.GRPCWeb)
 
-	staked, err := sdktypes.ParseCoinNormalized(conf.Validator.Staked)
+	staked, err := sdktypes.ParseCoinNormalized(conf.ListValidators()[0].Staked)
 	if err != nil {
 		return err
 	}
@@ -158,7 +158,7 @@ func (p *stargatePlugin) Start(ctx context.Context, runner chaincmdrunner.Runner
 		"--grpc.address",
 		conf.Host.GRPC,
 	)
-	return &CannotStartAppError{p.app.Name, err}
+	return &CannotStartAppError{AppName: p.app.Name, Err: err, AppPath: p.app.Root()}
 }
 
 func (p *stargatePlugin) Home() string {
@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+)
+
+// stargate46Plugin 是 Cosmos SDK 0.46 及更高版本的插件。
+// 客戶端的 block 廣播模式在 0.46 中被棄用並在 0.47 中移除，因此改用 sync 模式。
+type stargate46Plugin struct {
+	*stargatePlugin
+}
+
+func newStargate46Plugin(app App) *stargate46Plugin {
+	return &stargate46Plugin{
+		stargatePlugin: newStargatePlugin(app),
+	}
+}
+
+func (p *stargate46Plugin) Name() string {
+	return "Stargate 0.46+"
+}
+
+func (p *stargate46Plugin) Configure(homePath string, conf chainconfig.Config) error {
+	if err := p.stargatePlugin.Configure(homePath, conf); err != nil {
+		return err
+	}
+
+	path := nodeClientTOMLPath(homePath)
+	config, err := toml.LoadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	config.Set("broadcast-mode", "sync")
+	return saveTOML(path, config)
+}
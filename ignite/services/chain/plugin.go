@@ -2,9 +2,14 @@ package chain
 
 import (
 	"context"
+	"fmt"
+	"sync"
+
+	"github.com/blang/semver"
 
 	"github.com/ignite-hq/cli/ignite/chainconfig"
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
 )
 
 // TODO 省略 Stargate 的 -cli 日誌消息。
@@ -26,6 +31,77 @@ type Plugin interface {
 	Home() string
 }
 
+// PluginFactory 為應用程序創建插件。
+type PluginFactory func(app App) Plugin
+
+// pluginEntry 是為一個 Cosmos SDK 版本範圍註冊的插件。
+type pluginEntry struct {
+	family   cosmosver.Family
+	versions semver.Range
+	factory  PluginFactory
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   []pluginEntry
+)
+
+func init() {
+	MustRegisterPlugin(cosmosver.Stargate, ">=0.40.0 <0.46.0-alpha", func(app App) Plugin {
+		return newStargatePlugin(app)
+	})
+	MustRegisterPlugin(cosmosver.Stargate, ">=0.46.0-alpha", func(app App) Plugin {
+		return newStargate46Plugin(app)
+	})
+}
+
+// RegisterPlugin 為 Cosmos SDK 版本族 family 中語義版本滿足 versionRange 的鏈註冊插件，
+// 範圍使用 github.com/blang/semver 的語法，例如 ">=0.46.0 <0.48.0"。
+// 多個插件匹配同一個版本時，使用最後註冊的插件，因此項目可以覆蓋內置插件而無需修改 services/chain。
+func RegisterPlugin(family cosmosver.Family, versionRange string, factory PluginFactory) error {
+	versions, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return fmt.Errorf("無效的版本範圍 %q: %w", versionRange, err)
+	}
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	plugins = append(plugins, pluginEntry{
+		family:   family,
+		versions: versions,
+		factory:  factory,
+	})
+
+	return nil
+}
+
+// MustRegisterPlugin 與 RegisterPlugin 相同，但在版本範圍無效時 panic。
+func MustRegisterPlugin(family cosmosver.Family, versionRange string, factory PluginFactory) {
+	if err := RegisterPlugin(family, versionRange, factory); err != nil {
+		panic(err)
+	}
+}
+
+// pickPlugin 返回為鏈的 Cosmos SDK 版本註冊的插件，沒有匹配的插件時使用 Stargate 插件。
 func (c *Chain) pickPlugin() Plugin {
+	if factory := pluginFactory(c.Version); factory != nil {
+		return factory(c.app)
+	}
 	return newStargatePlugin(c.app)
 }
+
+// pluginFactory 返回最後為版本 v 註冊的插件工廠。
+func pluginFactory(v cosmosver.Version) PluginFactory {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	for i := len(plugins) - 1; i >= 0; i-- {
+		p := plugins[i]
+		if p.family == v.Family && p.versions(v.Semantic) {
+			return p.factory
+		}
+	}
+
+	return nil
+}
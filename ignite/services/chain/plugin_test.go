@@ -0,0 +1,73 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosver"
+)
+
+func TestPickPlugin(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v0.40.0", "Stargate"},
+		{"v0.45.4", "Stargate"},
+		{"v0.46.0-rc1", "Stargate 0.46+"},
+		{"v0.46.1", "Stargate 0.46+"},
+		{"v0.47.0", "Stargate 0.46+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := cosmosver.Parse(tt.version)
+			require.NoError(t, err)
+
+			c := &Chain{Version: v}
+			require.Equal(t, tt.want, c.pickPlugin().Name())
+		})
+	}
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	defer func(registered []pluginEntry) { plugins = registered }(plugins)
+
+	require.Error(t, RegisterPlugin(cosmosver.Stargate, "not a range", nil))
+
+	err := RegisterPlugin(cosmosver.Stargate, ">=0.47.0", func(app App) Plugin {
+		return &stargate46Plugin{stargatePlugin: &stargatePlugin{app: App{Name: "custom"}}}
+	})
+	require.NoError(t, err)
+
+	v, err := cosmosver.Parse("v0.47.1")
+	require.NoError(t, err)
+	c := &Chain{Version: v}
+	require.Equal(t, "custom", c.pickPlugin().(*stargate46Plugin).app.Name)
+
+	v, err = cosmosver.Parse("v0.46.1")
+	require.NoError(t, err)
+	c = &Chain{Version: v}
+	require.Equal(t, "Stargate 0.46+", c.pickPlugin().Name())
+}
+
+func TestStargate46PluginConfigure(t *testing.T) {
+	home := t.TempDir()
+	writeFiles(t, home, map[string]string{
+		"config/app.toml":    "[grpc]\nenable = true\n",
+		"config/config.toml": "",
+		"config/client.toml": "broadcast-mode = \"block\"\n",
+	})
+
+	conf := chainconfig.DefaultConf
+	conf.Validator = chainconfig.Validator{Name: "alice", Staked: "100stake"}
+	require.NoError(t, newStargate46Plugin(App{}).Configure(home, conf))
+
+	client, err := toml.LoadFile(nodeClientTOMLPath(home))
+	require.NoError(t, err)
+	require.Equal(t, "sync", client.Get("broadcast-mode"))
+	require.Equal(t, "test", client.Get("keyring-backend"))
+}
@@ -0,0 +1,207 @@
+package chain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cyclonedx"
+	"github.com/ignite-hq/cli/ignite/pkg/gocmd"
+	"github.com/ignite-hq/cli/ignite/pkg/gomodule"
+	"github.com/ignite-hq/cli/ignite/pkg/xgit"
+)
+
+const (
+	// envSourceDateEpoch 是設置發布文件時間戳的環境變量，
+	// 參見 https://reproducible-builds.org/specs/source-date-epoch/。
+	envSourceDateEpoch = "SOURCE_DATE_EPOCH"
+
+	// ldflagEmptyBuildID 將 Go 的構建 ID 固定為空，使二進製文件可以重現。
+	ldflagEmptyBuildID = "-buildid="
+)
+
+// ReleaseManifest 描述發布中一個目標的構建，驗證者可以用它獨立地重現並驗證二進製文件。
+type ReleaseManifest struct {
+	// Name 是鏈的名稱。
+	Name string `json:"name"`
+
+	// GOOS 和 GOARCH 是構建的目標平台。
+	GOOS   string `json:"goos"`
+	GOARCH string `json:"goarch"`
+
+	// Binary 是 tarball 中二進製文件的名稱，BinaryChecksum 是其 SHA256 校驗和。
+	Binary         string `json:"binary"`
+	BinaryChecksum string `json:"binary_checksum"`
+
+	// Tarball 是包含二進製文件的 tarball 的名稱。
+	Tarball string `json:"tarball"`
+
+	// GoVersion 是構建使用的 Go 版本。
+	GoVersion string `json:"go_version"`
+
+	// SDKVersion 是鏈使用的 Cosmos SDK 版本。
+	SDKVersion string `json:"sdk_version"`
+
+	// Commit 是構建的源代碼的 git 提交。
+	Commit string `json:"commit"`
+
+	// SourceDateEpoch 是 tarball 中文件的修改時間。
+	SourceDateEpoch int64 `json:"source_date_epoch"`
+
+	// BuildFlags 是傳遞給 go build 的參數。
+	BuildFlags []string `json:"build_flags"`
+}
+
+// releaseInfo 保存發布中所有目標共享的構建信息。
+type releaseInfo struct {
+	goVersion  string
+	commit     string
+	sourceDate time.Time
+}
+
+// releaseInfo 返回發布的構建信息。
+// 文件時間戳來自 SOURCE_DATE_EPOCH 環境變量，未設置時使用 HEAD 提交的時間。
+func (c *Chain) releaseInfo(ctx context.Context) (releaseInfo, error) {
+	goVersion, err := gocmd.Version(ctx)
+	if err != nil {
+		return releaseInfo{}, err
+	}
+
+	commit, commitTime, err := xgit.HeadCommit(c.app.Path)
+	if err != nil {
+		return releaseInfo{}, err
+	}
+
+	sourceDate, err := sourceDateEpoch(commitTime)
+	if err != nil {
+		return releaseInfo{}, err
+	}
+
+	return releaseInfo{
+		goVersion:  goVersion,
+		commit:     commit,
+		sourceDate: sourceDate,
+	}, nil
+}
+
+// sourceDateEpoch 返回 SOURCE_DATE_EPOCH 環境變量的時間，未設置時返回 fallback，
+// fallback 為零值時返回 Unix 紀元。
+func sourceDateEpoch(fallback time.Time) (time.Time, error) {
+	if epoch := os.Getenv(envSourceDateEpoch); epoch != "" {
+		sec, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("無效的 %s 值 %q", envSourceDateEpoch, epoch)
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	if fallback.IsZero() {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	return fallback.Truncate(time.Second).UTC(), nil
+}
+
+// writeReleaseTarball 將目錄 dir 中的文件寫入 gzip 壓縮的 tarball。
+// 條目按名稱排序，修改時間固定為 mtime 並且不包含所有者信息，
+// 因此相同的文件總是產生相同的 tarball。
+func writeReleaseTarball(tarPath, dir string, mtime time.Time) error {
+	f, err := os.Create(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		header.ModTime = mtime
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
+		header.Format = tar.FormatUSTAR
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeReleaseManifest 將目標的清單寫入 JSON 文件。
+func writeReleaseManifest(path string, manifest ReleaseManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// writeReleaseSBOM 根據鏈的 go.mod 生成 CycloneDX SBOM 並寫入 path。
+func (c *Chain) writeReleaseSBOM(path string, info releaseInfo) error {
+	gomod, err := gomodule.ParseAt(c.app.Path)
+	if err != nil {
+		return err
+	}
+
+	version := c.sourceVersion.tag
+	if version == "" {
+		version = info.commit
+	}
+
+	data, err := json.MarshalIndent(cyclonedx.FromGoMod(gomod, version, info.sourceDate), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
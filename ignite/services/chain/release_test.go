@@ -0,0 +1,68 @@
+package chain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReleaseTarball(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "marsd"), []byte("binary"), 0755))
+
+	mtime := time.Unix(1650000000, 0).UTC()
+	first := filepath.Join(t.TempDir(), "first.tar.gz")
+	second := filepath.Join(t.TempDir(), "second.tar.gz")
+
+	require.NoError(t, writeReleaseTarball(first, dir, mtime))
+
+	// 文件的修改時間不應影響 tarball。
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "marsd"), time.Now(), time.Now()))
+	require.NoError(t, writeReleaseTarball(second, dir, mtime))
+
+	firstData, err := os.ReadFile(first)
+	require.NoError(t, err)
+	secondData, err := os.ReadFile(second)
+	require.NoError(t, err)
+	require.Equal(t, firstData, secondData)
+
+	f, err := os.Open(first)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	header, err := tar.NewReader(gr).Next()
+	require.NoError(t, err)
+	require.Equal(t, "marsd", header.Name)
+	require.Equal(t, mtime, header.ModTime.UTC())
+	require.Equal(t, int64(0755), header.Mode)
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	commitTime := time.Unix(1650000000, 500)
+
+	t.Setenv(envSourceDateEpoch, "")
+	date, err := sourceDateEpoch(commitTime)
+	require.NoError(t, err)
+	require.Equal(t, int64(1650000000), date.Unix())
+
+	date, err = sourceDateEpoch(time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), date.Unix())
+
+	t.Setenv(envSourceDateEpoch, "1700000000")
+	date, err = sourceDateEpoch(commitTime)
+	require.NoError(t, err)
+	require.Equal(t, int64(1700000000), date.Unix())
+
+	t.Setenv(envSourceDateEpoch, "yesterday")
+	_, err = sourceDateEpoch(commitTime)
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/ignite-hq/cli/ignite/chainconfig"
 	"github.com/ignite-hq/cli/ignite/pkg/cache"
 	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosexplorer"
 	"github.com/ignite-hq/cli/ignite/pkg/cosmosfaucet"
 	"github.com/ignite-hq/cli/ignite/pkg/dirchange"
 	"github.com/ignite-hq/cli/ignite/pkg/localfs"
@@ -57,6 +59,10 @@ var (
 type serveOptions struct {
 	forceReset bool
 	resetOnce  bool
+	snapshot   string
+	cosmovisor bool
+	target     string
+	reload     <-chan struct{}
 }
 
 func newServeOption() serveOptions {
@@ -83,6 +89,29 @@ func ServeResetOnce() ServeOption {
 	}
 }
 
+// ServeSnapshot 允許在鏈服務時從名為 name 的快照恢復狀態
+func ServeSnapshot(name string) ServeOption {
+	return func(c *serveOptions) {
+		c.snapshot = name
+	}
+}
+
+// ServeCosmovisor 允許在 cosmovisor 下運行鏈節點，以便在本地測試升級。
+// 構建的二進製文件被作為創世二進製文件放在鏈主目錄的 cosmovisor 目錄中。
+func ServeCosmovisor() ServeOption {
+	return func(c *serveOptions) {
+		c.cosmovisor = true
+	}
+}
+
+// ServeReloadTrigger 使 build.watch.manual 啟用時的手動重新加載由 reload 觸發，
+// 而不是由標準輸入和 SIGHUP 信號觸發。同時服務多條鏈時用於共享一個觸發器。
+func ServeReloadTrigger(reload <-chan struct{}) ServeOption {
+	return func(c *serveOptions) {
+		c.reload = reload
+	}
+}
+
 // 服務提供應用程序。
 func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options ...ServeOption) error {
 	serveOptions := newServeOption()
@@ -106,9 +135,34 @@ func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options .
 		return err
 	}
 
+	c.cosmovisor = serveOptions.cosmovisor
+	c.target = serveOptions.target
+	c.reloadTrigger = serveOptions.reload
+	if err := c.checkTarget(); err != nil {
+		return err
+	}
+
+	// 確保快照存在
+	if serveOptions.snapshot != "" {
+		if _, err := c.Snapshot(serveOptions.snapshot); err != nil {
+			return err
+		}
+	}
+
 	// 開始服務組件。
 	g, ctx := errgroup.WithContext(ctx)
 
+	// 狀態端點在整個 serve 期間運行，在構建和重啟期間也可以查詢
+	if healthHost := c.healthHost(); healthHost != "" {
+		g.Go(func() error {
+			return c.runHealthServer(ctx, healthHost)
+		})
+
+		healthAddr, _ := xurl.HTTP(healthHost)
+		fmt.Fprintf(c.stdLog().out, "🌍 熊網鏈狀態: %s\n", healthAddr)
+		c.emit(ServeEventHealthStarted, map[string]interface{}{"address": healthAddr})
+	}
+
 	//區塊鏈節點例程
 	g.Go(func() error {
 		c.refreshServe()
@@ -127,68 +181,18 @@ func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options .
 					return err
 				}
 
-				var (
-					serveCtx context.Context
-					buildErr *CannotBuildAppError
-					startErr *CannotStartAppError
-				)
+				var serveCtx context.Context
 				serveCtx, c.serveCancel = context.WithCancel(ctx)
 
 				// 確定鍊是否應該重置狀態
 				shouldReset := serveOptions.forceReset || serveOptions.resetOnce
 
 				// 為應用程序服務。
-				err = c.serve(serveCtx, cacheStorage, shouldReset)
+				err = c.serve(serveCtx, cacheStorage, shouldReset, serveOptions.snapshot)
 				serveOptions.resetOnce = false
+				serveOptions.snapshot = ""
 
-				switch {
-				case err == nil:
-				case errors.Is(err, context.Canceled):
-					//如果應用程序已經被服務，我們保存創世狀態
-					if c.served {
-						c.served = false
-
-						fmt.Fprintln(c.stdLog().out, "💿 保存熊網鏈創世狀態...")
-
-						// 如果服務已停止，則保存創世狀態
-						if err := c.saveChainState(context.TODO(), commands); err != nil {
-							fmt.Fprint(c.stdLog().err, err.Error())
-							return err
-						}
-
-						genesisPath, err := c.exportedGenesisPath()
-						if err != nil {
-							fmt.Fprintln(c.stdLog().err, err.Error())
-							return err
-						}
-						fmt.Fprintf(c.stdLog().out, "💿 熊網鏈創世狀態保存在 %s\n", genesisPath)
-					}
-				case errors.As(err, &buildErr):
-					fmt.Fprintf(c.stdLog().err, "%s\n", errorColor(err.Error()))
-
-					var validationErr *chainconfig.ValidationError
-					if errors.As(err, &validationErr) {
-						fmt.Fprintln(c.stdLog().out, "請查看: https://github.com/ignite-hq/cli#configure")
-					}
-
-					fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("在重試之前等待修復..."))
-
-				case errors.As(err, &startErr):
-					// 解析返回的錯誤日誌
-					parsedErr := startErr.ParseStartError()
-
-					// 如果為空，我們無法識別錯誤
-					// 因此，該錯誤可能是由於與舊應用狀態不兼容的新邏輯引起的
-					// 我們建議用戶最終重置應用狀態
-					if parsedErr == "" {
-						fmt.Fprintf(c.stdLog().out, "%s %s\n", infoColor(`區塊鏈無法啟動。如果新代碼不再與保存的狀態兼容，您可以通過啟動來重置數據庫:`), "ignite chain serve --reset-once")
-
-						return fmt.Errorf("不能啟動 %s", startErr.AppName)
-					}
-
-					// 返回明確的解析錯誤
-					return errors.New(parsedErr)
-				default:
+				if err := c.handleServeError(commands, err); err != nil {
 					return err
 				}
 			}
@@ -203,6 +207,74 @@ func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options .
 	return g.Wait()
 }
 
+// handleServeError 處理一次 serve 返回的錯誤：停止時保存創世狀態，構建錯誤時等待修復。
+// 返回 nil 時 serve 繼續等待下一次刷新，否則 serve 以返回的錯誤結束。
+func (c *Chain) handleServeError(commands chaincmdrunner.Runner, err error) error {
+	var (
+		buildErr *CannotBuildAppError
+		startErr *CannotStartAppError
+	)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled):
+		//如果應用程序已經被服務，我們保存創世狀態
+		if c.served {
+			c.served = false
+
+			fmt.Fprintln(c.stdLog().out, "💿 保存熊網鏈創世狀態...")
+
+			// 如果服務已停止，則保存創世狀態
+			if err := c.saveChainState(context.TODO(), commands); err != nil {
+				fmt.Fprint(c.stdLog().err, err.Error())
+				return err
+			}
+
+			genesisPath, err := c.exportedGenesisPath()
+			if err != nil {
+				fmt.Fprintln(c.stdLog().err, err.Error())
+				return err
+			}
+			fmt.Fprintf(c.stdLog().out, "💿 熊網鏈創世狀態保存在 %s\n", genesisPath)
+			c.emit(ServeEventStateSaved, map[string]interface{}{"path": genesisPath})
+		}
+	case errors.As(err, &buildErr):
+		fmt.Fprintf(c.stdLog().err, "%s\n", errorColor(err.Error()))
+		c.emit(ServeEventBuildFailed, map[string]interface{}{"error": buildErr.Err.Error()})
+
+		var validationErr *chainconfig.ValidationError
+		if errors.As(err, &validationErr) {
+			fmt.Fprintln(c.stdLog().out, "請查看: https://github.com/ignite-hq/cli#configure")
+		}
+
+		fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("在重試之前等待修復..."))
+
+	case errors.As(err, &startErr):
+		// 解析返回的錯誤日誌
+		parsedErr := startErr.ParseStartError()
+		if parsedErr != "" {
+			c.emit(ServeEventStartFailed, map[string]interface{}{"error": parsedErr})
+		} else {
+			c.emit(ServeEventStartFailed, map[string]interface{}{"error": startErr.Error()})
+		}
+
+		// 如果為空，我們無法識別錯誤
+		// 因此，該錯誤可能是由於與舊應用狀態不兼容的新邏輯引起的
+		// 我們建議用戶最終重置應用狀態
+		if parsedErr == "" {
+			fmt.Fprintf(c.stdLog().out, "%s %s\n", infoColor(`區塊鏈無法啟動。如果新代碼不再與保存的狀態兼容，您可以通過啟動來重置數據庫:`), "ignite chain serve --reset-once")
+
+			return fmt.Errorf("不能啟動 %s", startErr.AppName)
+		}
+
+		// 返回明確的解析錯誤
+		return errors.New(parsedErr)
+	default:
+		return err
+	}
+	return nil
+}
+
 func (c *Chain) setup() error {
 	fmt.Fprintf(c.stdLog().out, "熊網鏈版本是: %s\n\n", infoColor(c.Version))
 
@@ -227,26 +299,75 @@ func (c *Chain) refreshServe() {
 }
 
 func (c *Chain) watchAppBackend(ctx context.Context) error {
-	watchPaths := appBackendSourceWatchPaths
-	if c.ConfigPath() != "" {
-		watchPaths = append(watchPaths, c.ConfigPath())
+	// 配置無效時使用默認的監視設置，serve 會報告配置錯誤
+	var (
+		watch    chainconfig.Watch
+		fixtures []string
+	)
+	if conf, err := c.Config(); err == nil {
+		watch = conf.Build.Watch
+		fixtures = conf.GenesisFixtures
 	}
 
-	return localfs.Watch(
-		ctx,
-		watchPaths,
+	options := []localfs.WatcherOption{
 		localfs.WatcherWorkdir(c.app.Path),
-		localfs.WatcherOnChange(c.refreshServe),
+		localfs.WatcherOnChange(func() {
+			c.emit(ServeEventSourceChanged, nil)
+			c.refreshServe()
+		}),
 		localfs.WatcherIgnoreHidden(),
 		localfs.WatcherIgnoreFolders(),
 		localfs.WatcherIgnoreExt(ignoredExts...),
-	)
+		localfs.WatcherExclude(watch.Exclude...),
+	}
+
+	// 創世數據的修改與配置的修改一樣會重置鏈狀態
+	watchPaths := append(c.ConfigPaths(), fixtures...)
+	if len(watch.Include) == 0 {
+		watchPaths = append(watchPaths, appBackendSourceWatchPaths...)
+	} else {
+		options = append(options, localfs.WatcherInclude(watch.Include...))
+	}
+
+	if watch.Debounce != "" {
+		debounce, err := time.ParseDuration(watch.Debounce)
+		if err != nil {
+			return err
+		}
+		options = append(options, localfs.WatcherDebounce(debounce))
+	}
+
+	if watch.Manual {
+		fmt.Fprintf(c.stdLog().out, "%s\n", infoColor("⌨️  手動重新加載已啟用，按回車鍵或發送 SIGHUP 信號以重新構建"))
+		reload := c.reloadTrigger
+		if reload == nil {
+			reload = localfs.ReloadTrigger(ctx, os.Stdin)
+		}
+		options = append(options, localfs.WatcherManualReload(reload))
+	}
+
+	return localfs.Watch(ctx, watchPaths, options...)
+}
+
+// sourceWatchPaths 返回計算源代碼校驗和的文件，即與 serve 監視的源代碼相同，
+// 匹配 build.watch.include（默認為 app、cmd、x、proto 和 third_party 目錄）且不匹配 build.watch.exclude 的文件。
+func sourceWatchPaths(root string, watch chainconfig.Watch) ([]string, error) {
+	include := watch.Include
+	if len(include) == 0 {
+		include = appBackendSourceWatchPaths
+	}
+	return localfs.GlobFiles(root, include, watch.Exclude)
 }
 
 // serve 執行為區塊鏈服務的操作：構建、初始化和啟動
 // 如果鏈已經初始化並且文件沒有改變，則直接啟動應用程序
 // 如果文件改變了，狀態被導入
-func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceReset bool) error {
+// 如果提供了快照，狀態將從快照恢復
+func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceReset bool, snapshot string) error {
+	if err := c.ValidateConfig(); err != nil {
+		return &CannotBuildAppError{err}
+	}
+
 	conf, err := c.Config()
 	if err != nil {
 		return &CannotBuildAppError{err}
@@ -257,8 +378,16 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		return err
 	}
 
-	// isInit 判斷應用是否初始化
-	var isInit bool
+	var (
+		// isInit 判斷應用是否初始化
+		isInit bool
+
+		// reconfigure 表示節點配置必須在保留狀態的情況下重新應用
+		reconfigure bool
+
+		// configBuildModified 表示構建配置已修改，應用必須重新構建
+		configBuildModified bool
+	)
 
 	dirCache := cache.New[[]byte](cacheStorage, serveDirchangeCacheNamespace)
 
@@ -269,35 +398,40 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		return err
 	}
 	if isInit {
-		configModified := false
+		configChange := configUnchanged
 		if c.ConfigPath() != "" {
-			configModified, err = dirchange.HasDirChecksumChanged(dirCache, configChecksumKey, c.app.Path, c.ConfigPath())
+			configChange, err = c.detectConfigChange(dirCache, conf)
 			if err != nil {
 				return err
 			}
 		}
 
-		if forceReset || configModified {
-			// 如果設置了 forceReset，我們認為應用程序沒有初始化
+		if forceReset || configChange == configStateChanged {
+			// 如果設置了 forceReset 或影響狀態的配置已修改，我們認為應用程序沒有初始化
 			fmt.Fprintln(c.stdLog().out, "🔄 重置熊網鏈應用狀態...")
+			c.emit(ServeEventStateReset, nil)
 			isInit = false
+		} else {
+			// 其他配置修改可以在保留狀態的情況下重新應用
+			reconfigure = configChange >= configRuntimeChanged
+			configBuildModified = configChange == configBuildChanged
 		}
 	}
 
 	// 檢查自上次服務以來是否已修改源
 	// 如果狀態不能被重置但源已經改變，我們重建鏈並導入導出的狀態
-	sourceModified, err := dirchange.HasDirChecksumChanged(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...)
+	sourcePaths, err := sourceWatchPaths(c.app.Path, conf.Build.Watch)
 	if err != nil {
 		return err
 	}
-
-	// 我們還考慮了校驗和中的二進製文件，以確保二進製文件未被第三方更改
-	var binaryModified bool
-	binaryName, err := c.Binary()
+	sourceModified, err := dirchange.HasDirChecksumChanged(dirCache, sourceChecksumKey, c.app.Path, sourcePaths...)
 	if err != nil {
 		return err
 	}
-	binaryPath, err := exec.LookPath(binaryName)
+
+	// 我們還考慮了校驗和中的二進製文件，以確保二進製文件未被第三方更改
+	var binaryModified bool
+	binaryPath, err := c.binaryPath()
 	if err != nil {
 		if !errors.Is(err, exec.ErrNotFound) {
 			return err
@@ -310,7 +444,19 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		}
 	}
 
-	appModified := sourceModified || binaryModified
+	appModified := sourceModified || binaryModified || configBuildModified
+
+	// 只有不影響狀態的源代碼（例如 keeper 邏輯）被修改時，重新構建後在已有數據上重啟節點，
+	// 而不是導出並重新導入狀態
+	stateSurface, err := c.stateSurfaceChecksum(ctx, conf)
+	if err != nil {
+		return err
+	}
+	stateSurfaceModified, err := isStateSurfaceChanged(dirCache, stateSurface)
+	if err != nil {
+		return err
+	}
+	incremental := isInit && sourceModified && !stateSurfaceModified && !binaryModified && !configBuildModified && snapshot == ""
 
 	// 檢查導出的創世紀是否存在
 	exportGenesisExists := true
@@ -334,16 +480,21 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 
 	// 初始階段
 	// 不願意：gocritic
-	if !isInit || (appModified && !exportGenesisExists) {
+	if !isInit || (appModified && !incremental && !exportGenesisExists) {
 		fmt.Fprintln(c.stdLog().out, "💿 初始化熊網鏈應用程序...")
+		c.emit(ServeEventInit, nil)
 
 		if err := c.Init(ctx, true); err != nil {
 			return err
 		}
+	} else if incremental {
+		fmt.Fprintln(c.stdLog().out, "⚡ 只修改了不影響狀態的源代碼，在現有數據上重啟熊網鏈應用...")
+		c.emit(ServeEventIncrementalRestart, nil)
 	} else if appModified {
 		// 如果鏈已經初始化但源已被修改
 		// 我們重置鏈數據庫並導入創世狀態
 		fmt.Fprintln(c.stdLog().out, "💿 檢測到存在的創世起源，正在恢復數據庫...")
+		c.emit(ServeEventStateImport, map[string]interface{}{"path": exportedGenesisPath})
 
 		if err := commands.UnsafeReset(ctx); err != nil {
 			return err
@@ -352,20 +503,52 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		if err := c.importChainState(); err != nil {
 			return err
 		}
-	} else {
+
+		if err := c.resetValidatorNodes(ctx, conf); err != nil {
+			return err
+		}
+	} else if !reconfigure {
 		fmt.Fprintln(c.stdLog().out, "▶️  重啟熊網鏈現有應用...")
+		c.emit(ServeEventRestart, nil)
+	}
+
+	// 在保留狀態的情況下重新應用修改過的配置
+	if isInit && reconfigure && (!appModified || incremental || exportGenesisExists) {
+		fmt.Fprintln(c.stdLog().out, "🔧 檢測到配置修改，重新配置熊網鏈應用並保留狀態...")
+		c.emit(ServeEventReconfigure, nil)
+
+		if err := c.reconfigure(ctx, conf); err != nil {
+			return err
+		}
+	}
+
+	// 從快照恢復狀態
+	if snapshot != "" {
+		fmt.Fprintf(c.stdLog().out, "💿 從快照 %q 恢復熊網鏈狀態...\n", snapshot)
+		c.emit(ServeEventSnapshotRestore, map[string]interface{}{"name": snapshot})
+
+		if err := c.RestoreSnapshot(ctx, snapshot); err != nil {
+			return err
+		}
 	}
 
 	// 保存校驗和
 	if c.ConfigPath() != "" {
-		if err := dirchange.SaveDirChecksum(dirCache, configChecksumKey, c.app.Path, c.ConfigPath()); err != nil {
+		if err := c.saveConfigChecksums(dirCache, conf); err != nil {
 			return err
 		}
 	}
-	if err := dirchange.SaveDirChecksum(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...); err != nil {
+	// 構建可能生成了新的源文件，因此重新列出文件。
+	if sourcePaths, err = sourceWatchPaths(c.app.Path, conf.Build.Watch); err != nil {
+		return err
+	}
+	if err := dirchange.SaveDirChecksum(dirCache, sourceChecksumKey, c.app.Path, sourcePaths...); err != nil {
+		return err
+	}
+	if err := dirCache.Put(stateSurfaceChecksumKey, []byte(stateSurface)); err != nil {
 		return err
 	}
-	binaryPath, err = exec.LookPath(binaryName)
+	binaryPath, err = c.binaryPath()
 	if err != nil {
 		return err
 	}
@@ -373,6 +556,13 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		return err
 	}
 
+	// 將二進製文件放到 cosmovisor 目錄中
+	if c.cosmovisor {
+		if err := c.prepareCosmovisor(binaryPath, !isInit || (appModified && !incremental) || snapshot != ""); err != nil {
+			return err
+		}
+	}
+
 	// 啟動區塊鏈
 	return c.start(ctx, conf)
 }
@@ -383,23 +573,60 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 		return err
 	}
 
+	nodes, err := c.validatorNodes(config)
+	if err != nil {
+		return err
+	}
+
+	if c.isDocker() {
+		c.removeNodeContainers(ctx, nodes)
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
-	// 啟動區塊鏈。
-	g.Go(func() error { return c.plugin.Start(ctx, commands, config) })
+	// 啟動區塊鏈及其他驗證者節點，每個節點的日誌寫入各自主目錄下的日誌文件。
+	for i, n := range nodes {
+		nodeCommands := commands
+		if i > 0 {
+			if nodeCommands, err = c.nodeCommands(ctx, n); err != nil {
+				return err
+			}
+		}
+
+		nodeCommands, closeLog, err := c.nodeLogCommands(nodeCommands, n, i == 0)
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+
+		nodeConfig := config
+		nodeConfig.Host = n.host
+
+		g.Go(func() error { return c.plugin.Start(ctx, nodeCommands, nodeConfig) })
+	}
 
 	// 如果啟用，請啟動水龍頭。
 	faucet, err := c.Faucet(ctx)
 	isFaucetEnabled := err != ErrFaucetIsNotEnabled
 
 	if isFaucetEnabled {
-		if err == ErrFaucetAccountDoesNotExist {
+		if errors.Is(err, ErrFaucetAccountDoesNotExist) {
 			return &CannotBuildAppError{errors.Wrap(err, "水龍頭帳戶不存在")}
 		}
 		if err != nil {
 			return err
 		}
 
+		var faucetAccounts []chaincmdrunner.Account
+		for _, name := range append([]string{*config.Faucet.Name}, config.Faucet.Accounts...) {
+			account, err := commands.ShowAccount(ctx, name)
+			if err != nil {
+				return err
+			}
+			faucetAccounts = append(faucetAccounts, account)
+		}
+		c.status.setFaucet(faucetAccounts...)
+
 		g.Go(func() (err error) {
 			if err := c.runFaucetServer(ctx, faucet); err != nil {
 				return &CannotBuildAppError{err}
@@ -408,21 +635,58 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 		})
 	}
 
-	// 將應用設置為正在服務
-	c.served = true
-
 	// 注意：地址格式錯誤由錯誤組，因此可以在這里安全地忽略它們
 
 	rpcAddr, _ := xurl.HTTP(config.Host.RPC)
 	apiAddr, _ := xurl.HTTP(config.Host.API)
 
+	// 如果配置了主機，啟動區塊瀏覽器。
+	isExplorerEnabled := config.Host.Explorer != ""
+
+	if isExplorerEnabled {
+		g.Go(func() error {
+			if err := c.runExplorerServer(ctx, config.Host.Explorer, rpcAddr, apiAddr); err != nil {
+				return &CannotBuildAppError{err}
+			}
+			return nil
+		})
+	}
+
+	// 將應用設置為正在服務
+	c.served = true
+
 	// 打印服務器地址。
 	fmt.Fprintf(c.stdLog().out, "🌍 熊網鏈節點: %s\n", rpcAddr)
 	fmt.Fprintf(c.stdLog().out, "🌍 熊網鏈API: %s\n", apiAddr)
+	c.emit(ServeEventNodeStarted, map[string]interface{}{
+		"validator": nodes[0].validator.Name,
+		"rpc":       rpcAddr,
+		"api":       apiAddr,
+		"log":       nodeLogPath(nodes[0].home),
+	})
+
+	for _, n := range nodes[1:] {
+		nodeRPCAddr, _ := xurl.HTTP(n.host.RPC)
+		fmt.Fprintf(c.stdLog().out, "🌍 驗證者節點 %s: %s\n", n.validator.Name, nodeRPCAddr)
+		nodeAPIAddr, _ := xurl.HTTP(n.host.API)
+		c.emit(ServeEventNodeStarted, map[string]interface{}{
+			"validator": n.validator.Name,
+			"rpc":       nodeRPCAddr,
+			"api":       nodeAPIAddr,
+			"log":       nodeLogPath(n.home),
+		})
+	}
 
 	if isFaucetEnabled {
 		faucetAddr, _ := xurl.HTTP(chainconfig.FaucetHost(config))
 		fmt.Fprintf(c.stdLog().out, "🌍 熊幣水龍頭: %s\n", faucetAddr)
+		c.emit(ServeEventFaucetStarted, map[string]interface{}{"address": faucetAddr})
+	}
+
+	if isExplorerEnabled {
+		explorerAddr, _ := xurl.HTTP(config.Host.Explorer)
+		fmt.Fprintf(c.stdLog().out, "🌍 熊網鏈瀏覽器: %s\n", explorerAddr)
+		c.emit(ServeEventExplorerStarted, map[string]interface{}{"address": explorerAddr})
 	}
 
 	return g.Wait()
@@ -440,6 +704,14 @@ func (c *Chain) runFaucetServer(ctx context.Context, faucet cosmosfaucet.Faucet)
 	})
 }
 
+// runExplorerServer 在 host 上啟動區塊瀏覽器，它從 rpcAddr 和 apiAddr 讀取鏈數據
+func (c *Chain) runExplorerServer(ctx context.Context, host, rpcAddr, apiAddr string) error {
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    host,
+		Handler: cosmosexplorer.New(rpcAddr, apiAddr),
+	})
+}
+
 // saveChainState 運行鏈的導出命令並將導出的創世紀存儲在鏈保存的配置中
 func (c *Chain) saveChainState(ctx context.Context, commands chaincmdrunner.Runner) error {
 	genesisPath, err := c.exportedGenesisPath()
@@ -511,6 +783,9 @@ func (e *CannotBuildAppError) Unwrap() error {
 type CannotStartAppError struct {
 	AppName string
 	Err     error
+
+	// AppPath 是應用程序源代碼的路徑，用於在 panic 的堆棧中找到出錯的源文件。
+	AppPath string
 }
 
 func (e *CannotStartAppError) Error() string {
@@ -521,18 +796,49 @@ func (e *CannotStartAppError) Unwrap() error {
 	return e.Err
 }
 
+var (
+	// addressInUseRe 匹配監聽地址已被佔用的錯誤。
+	addressInUseRe = regexp.MustCompile(`listen \S+ \S+: ?bind: address already in use|listen .* 綁定：地址已經在使用中`)
+
+	// panicRe 匹配 panic 的消息。
+	panicRe = regexp.MustCompile(`(?m)^panic: (.+)$`)
+
+	// stackFrameRe 匹配 panic 堆棧中的源文件位置。
+	stackFrameRe = regexp.MustCompile(`(?m)^\t(\S+\.go):(\d+)`)
+)
+
 // ParseStartError 將錯誤解析為明確的錯誤字符串
 // Cosmos SDK 應用程序的錯誤日誌太長，無法直接打印
 // 如果錯誤沒有被識別，返回一個空字符串
 func (e *CannotStartAppError) ParseStartError() string {
 	errorLogs := errors.Unwrap(e.Err).Error()
 	switch {
-	case strings.Contains(errorLogs, "綁定：地址已經在使用中"):
-		r := regexp.MustCompile(`listen .* 綁定：地址已經在使用中`)
-		return r.FindString(errorLogs)
-	case strings.Contains(errorLogs, "驗證器集在創世中為零"):
+	case addressInUseRe.MatchString(errorLogs):
+		return addressInUseRe.FindString(errorLogs)
+	case strings.Contains(errorLogs, "驗證器集在創世中為零"),
+		strings.Contains(errorLogs, "validator set is nil in genesis"):
 		return "錯誤：握手期間出錯：重放時出錯：驗證器集在創世中為零，並且在 InitChain 之後仍然為空"
+	case panicRe.MatchString(errorLogs):
+		return e.parsePanic(errorLogs)
 	default:
 		return ""
 	}
 }
+
+// parsePanic 返回 panic 的消息以及堆棧中第一個位於應用程序源代碼中的源文件位置。
+func (e *CannotStartAppError) parsePanic(errorLogs string) string {
+	msg := "panic: " + panicRe.FindStringSubmatch(errorLogs)[1]
+	if e.AppPath == "" {
+		return msg
+	}
+
+	for _, frame := range stackFrameRe.FindAllStringSubmatch(errorLogs, -1) {
+		rel, err := filepath.Rel(e.AppPath, frame[1])
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return fmt.Sprintf("%s\n出錯的源文件: %s:%s", msg, rel, frame[2])
+	}
+
+	return msg
+}
@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ServeEventType 是 serve 期間發出的生命週期事件的類型。
+type ServeEventType string
+
+const (
+	ServeEventBuildStarted       ServeEventType = "build_started"
+	ServeEventBuildFinished      ServeEventType = "build_finished"
+	ServeEventBuildFailed        ServeEventType = "build_failed"
+	ServeEventInit               ServeEventType = "init"
+	ServeEventStateReset         ServeEventType = "state_reset"
+	ServeEventStateImport        ServeEventType = "state_import"
+	ServeEventStateSaved         ServeEventType = "state_saved"
+	ServeEventSnapshotRestore    ServeEventType = "snapshot_restore"
+	ServeEventReconfigure        ServeEventType = "reconfigure"
+	ServeEventRestart            ServeEventType = "restart"
+	ServeEventIncrementalRestart ServeEventType = "incremental_restart"
+	ServeEventNodeStarted        ServeEventType = "node_started"
+	ServeEventFaucetStarted      ServeEventType = "faucet_started"
+	ServeEventExplorerStarted    ServeEventType = "explorer_started"
+	ServeEventHealthStarted      ServeEventType = "health_started"
+	ServeEventStartFailed        ServeEventType = "start_failed"
+	ServeEventSourceChanged      ServeEventType = "source_changed"
+	ServeEventFaucetLowBalance   ServeEventType = "faucet_low_balance"
+	ServeEventFaucetRefilled     ServeEventType = "faucet_refilled"
+	ServeEventFaucetRefillFailed ServeEventType = "faucet_refill_failed"
+)
+
+// ServeEvent 是 serve 期間以 JSON 格式逐行輸出的生命週期事件。
+type ServeEvent struct {
+	// Type 是事件的類型。
+	Type ServeEventType `json:"type"`
+
+	// Timestamp 是事件發生的時間。
+	Timestamp time.Time `json:"timestamp"`
+
+	// Payload 保存事件的附加數據。
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// eventWriter 將事件以換行分隔的 JSON 格式寫入輸出。
+type eventWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func newEventWriter(w io.Writer) *eventWriter {
+	return &eventWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *eventWriter) write(e ServeEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// 事件輸出是盡力而為的，寫入失敗不應中斷服務。
+	_ = w.enc.Encode(e)
+}
+
+// emit 在啟用事件輸出時發出一個生命週期事件。
+func (c *Chain) emit(typ ServeEventType, payload map[string]interface{}) {
+	if c.status != nil {
+		c.status.observe(typ, payload)
+	}
+
+	if c.events == nil {
+		return
+	}
+
+	c.events.write(ServeEvent{
+		Type:      typ,
+		Timestamp: time.Now().UTC(),
+		Payload:   payload,
+	})
+}
@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosfaucet"
+)
+
+func TestEmit(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		c   = &Chain{}
+	)
+
+	// events are dropped when the output is not enabled.
+	c.emit(ServeEventBuildStarted, nil)
+
+	EventOutput(&buf)(c)
+	c.emit(ServeEventBuildStarted, nil)
+	c.emit(ServeEventFaucetStarted, map[string]interface{}{"address": "http://0.0.0.0:4500"})
+
+	var events []ServeEvent
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e ServeEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+
+	require.Len(t, events, 2)
+	require.Equal(t, ServeEventBuildStarted, events[0].Type)
+	require.False(t, events[0].Timestamp.IsZero())
+	require.Nil(t, events[0].Payload)
+	require.Equal(t, ServeEventFaucetStarted, events[1].Type)
+	require.Equal(t, "http://0.0.0.0:4500", events[1].Payload["address"])
+}
+
+func TestFaucetEvent(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		c   = &Chain{stdout: io.Discard}
+	)
+	EventOutput(&buf)(c)
+
+	c.faucetEvent(cosmosfaucet.Event{
+		Type:    cosmosfaucet.EventRefillFailed,
+		Account: "faucet2",
+		Address: "cosmos1faucet2",
+		Balance: sdk.NewCoins(sdk.NewInt64Coin("token", 5)),
+		Coins:   sdk.NewCoins(sdk.NewInt64Coin("token", 1000)),
+		Err:     errors.New("insufficient funds"),
+	})
+
+	var e ServeEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &e))
+	require.Equal(t, ServeEventFaucetRefillFailed, e.Type)
+	require.Equal(t, map[string]interface{}{
+		"account": "faucet2",
+		"address": "cosmos1faucet2",
+		"balance": "5token",
+		"coins":   "1000token",
+		"error":   "insufficient funds",
+	}, e.Payload)
+}
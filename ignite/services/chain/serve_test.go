@@ -0,0 +1,129 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/dirchange"
+)
+
+func TestParseStartError(t *testing.T) {
+	startErr := func(logs string) *CannotStartAppError {
+		return &CannotStartAppError{
+			AppName: "mars",
+			Err:     errors.Wrap(errors.New("exit status 2"), logs),
+			AppPath: "/home/user/mars",
+		}
+	}
+
+	cases := []struct {
+		name, logs, want string
+	}{
+		{
+			name: "address in use",
+			logs: `{"level":"info","module":"p2p","message":"starting"}` + "\nError: listen tcp 0.0.0.0:26657: bind: address already in use\n",
+			want: "listen tcp 0.0.0.0:26657: bind: address already in use",
+		},
+		{
+			name: "panic in app source",
+			logs: `{"level":"info","module":"server","message":"starting ABCI with Tendermint"}
+panic: runtime error: invalid memory address or nil pointer dereference
+
+goroutine 1 [running]:
+github.com/cosmos/cosmos-sdk/baseapp.(*BaseApp).InitChain(...)
+	/root/go/pkg/mod/github.com/cosmos/cosmos-sdk@v0.45.4/baseapp/abci.go:67 +0x6a5
+github.com/user/mars/x/mars/keeper.Keeper.InitGenesis(...)
+	/home/user/mars/x/mars/keeper/genesis.go:12 +0x1d
+`,
+			want: "panic: runtime error: invalid memory address or nil pointer dereference\n出錯的源文件: x/mars/keeper/genesis.go:12",
+		},
+		{
+			name: "panic outside app source",
+			logs: "panic: oops\n\ngoroutine 1 [running]:\nmain.main()\n\t/usr/local/go/src/runtime/proc.go:10 +0x1\n",
+			want: "panic: oops",
+		},
+		{
+			name: "unknown error",
+			logs: "Error: wrong Block.Header.AppHash\n",
+			want: "",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, startErr(tt.logs).ParseStartError())
+		})
+	}
+}
+
+func TestHandleServeErrorSyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "x/mars/types/keys.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("package types\n\nconst StoreKey = \n"), 0644))
+
+	var events bytes.Buffer
+	c := &Chain{app: App{Path: dir}, stdout: io.Discard, stderr: io.Discard}
+	EventOutput(&events)(c)
+
+	_, err := c.stateSurfaceChecksum(context.Background(), chainconfig.Config{})
+	require.Error(t, err)
+
+	// serve waits for a fix instead of exiting.
+	require.NoError(t, c.handleServeError(chaincmdrunner.Runner{}, err))
+	require.Contains(t, events.String(), `"type":"build_failed"`)
+
+	require.Error(t, c.handleServeError(chaincmdrunner.Runner{}, errors.New("oops")))
+}
+
+func TestFaucetAccountDoesNotExistError(t *testing.T) {
+	err := errors.Wrap(&FaucetAccountDoesNotExistError{"faucet.accounts", "faucet2"}, "faucet")
+
+	// serve waits for a fix of any missing faucet account.
+	require.True(t, errors.Is(err, ErrFaucetAccountDoesNotExist))
+	require.EqualError(t, err, `faucet: 指定的帳戶（faucet.accounts）"faucet2" 不存在`)
+}
+
+func TestSourceWatchPathsChecksum(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app/app.go":               "package app",
+		"x/mars/keeper.go":         "package keeper",
+		"x/mars/types/query.pb.go": "package types",
+		"vendor/foo/foo.go":        "package foo",
+	})
+	watch := chainconfig.Watch{
+		Include: []string{"**/*.go"},
+		Exclude: []string{"**/*.pb.go", "vendor"},
+	}
+
+	checksum := func() []byte {
+		paths, err := sourceWatchPaths(root, watch)
+		require.NoError(t, err)
+		sum, err := dirchange.ChecksumFromPaths(root, paths...)
+		require.NoError(t, err)
+		return sum
+	}
+
+	sum := checksum()
+
+	// the excluded files don't change the checksum.
+	writeFiles(t, root, map[string]string{
+		"x/mars/types/query.pb.go": "package types // changed",
+		"vendor/foo/foo.go":        "package foo // changed",
+		"vendor/bar/bar.go":        "package bar",
+	})
+	require.Equal(t, sum, checksum())
+
+	writeFiles(t, root, map[string]string{"x/mars/keeper.go": "package keeper // changed"})
+	require.NotEqual(t, sum, checksum())
+}
@@ -0,0 +1,283 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite-hq/cli/ignite/pkg/dirchange"
+	"github.com/ignite-hq/cli/ignite/pkg/tendermintrpc"
+	"github.com/ignite-hq/cli/ignite/pkg/xfilepath"
+	"github.com/ignite-hq/cli/ignite/pkg/xurl"
+)
+
+const (
+	// snapshotGenesis 是快照中導出的創世文件的名稱
+	snapshotGenesis = "genesis.json"
+
+	// snapshotMetadata 是快照元數據文件的名稱
+	snapshotMetadata = "snapshot.json"
+)
+
+var (
+	// snapshotsPath 是保存鏈狀態快照的緩存目錄，每條鏈的快照位於以鏈 ID 命名的子目錄中。
+	snapshotsPath = xfilepath.Join(
+		xfilepath.PathWithError(os.UserCacheDir()),
+		xfilepath.Path("ignite"),
+		xfilepath.Path("snapshots"),
+	)
+
+	// ErrSnapshotNotFound 在找不到指定名稱的快照時返回。
+	ErrSnapshotNotFound = errors.New("找不到快照")
+
+	// ErrChainServed 在鏈的節點正在運行時保存或恢復快照時返回。
+	ErrChainServed = errors.New("鏈正在運行，請先停止 serve")
+
+	snapshotNameRe = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// Snapshot 保存鏈狀態快照的元數據。
+type Snapshot struct {
+	// Name 是快照的名稱。
+	Name string `json:"name"`
+
+	// Height 是導出狀態時的區塊高度。
+	Height int64 `json:"height"`
+
+	// SourceChecksum 是導出狀態時鏈源代碼的校驗和。
+	SourceChecksum string `json:"source_checksum"`
+
+	// CreatedAt 是快照的創建時間。
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SaveSnapshot 導出鏈的當前狀態並將其保存為名為 name 的快照。
+// 導出需要讀取節點的數據庫，因此鏈在運行中時返回 ErrChainServed。
+func (c *Chain) SaveSnapshot(ctx context.Context, name string) (Snapshot, error) {
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if err := checkNotServed(ctx, conf); err != nil {
+		return Snapshot{}, err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	genesisPath := filepath.Join(path, snapshotGenesis)
+	if err := commands.Export(ctx, genesisPath); err != nil {
+		return Snapshot{}, err
+	}
+
+	genesis, err := cosmosutil.ParseGenesisFromPath(genesisPath)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	sourcePaths, err := sourceWatchPaths(c.app.Path, conf.Build.Watch)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	sourceChecksum, err := dirchange.ChecksumFromPaths(c.app.Path, sourcePaths...)
+	if err != nil && !errors.Is(err, dirchange.ErrNoFile) {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Name:           name,
+		Height:         genesis.InitialHeight,
+		SourceChecksum: hex.EncodeToString(sourceChecksum),
+		CreatedAt:      time.Now(),
+	}
+
+	cf := confile.New(confile.DefaultJSONEncodingCreator, filepath.Join(path, snapshotMetadata))
+	return snapshot, cf.Save(snapshot)
+}
+
+// RestoreSnapshot 重置鏈的數據庫並以名為 name 的快照作為創世，
+// 下次啟動鏈時將從快照的狀態繼續。鏈在運行中時返回 ErrChainServed。
+func (c *Chain) RestoreSnapshot(ctx context.Context, name string) error {
+	snapshot, err := c.Snapshot(name)
+	if err != nil {
+		return err
+	}
+
+	path, err := c.snapshotPath(snapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	if err := checkNotServed(ctx, conf); err != nil {
+		return err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.UnsafeReset(ctx); err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	if err := copy.Copy(filepath.Join(path, snapshotGenesis), genesisPath); err != nil {
+		return err
+	}
+
+	// 將快照作為最新導出的狀態，以便在源代碼修改後導入。
+	exportedGenesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+
+	if err := copy.Copy(filepath.Join(path, snapshotGenesis), exportedGenesisPath); err != nil {
+		return err
+	}
+
+	return c.resetValidatorNodes(ctx, conf)
+}
+
+// Snapshot 返回名為 name 的快照。
+func (c *Chain) Snapshot(name string) (Snapshot, error) {
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	metadataPath := filepath.Join(path, snapshotMetadata)
+	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+		return Snapshot{}, errors.Wrap(ErrSnapshotNotFound, name)
+	}
+
+	var snapshot Snapshot
+	cf := confile.New(confile.DefaultJSONEncodingCreator, metadataPath)
+	return snapshot, cf.Load(&snapshot)
+}
+
+// Snapshots 返回鏈的所有快照，按創建時間排序。
+func (c *Chain) Snapshots() ([]Snapshot, error) {
+	path, err := c.chainSnapshotsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		snapshot, err := c.Snapshot(entry.Name())
+		if errors.Is(err, ErrSnapshotNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// DeleteSnapshot 刪除名為 name 的快照。
+func (c *Chain) DeleteSnapshot(name string) error {
+	if _, err := c.Snapshot(name); err != nil {
+		return err
+	}
+
+	path, err := c.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(path)
+}
+
+// snapshotPath 返回名為 name 的快照的保存路徑。
+func (c *Chain) snapshotPath(name string) (string, error) {
+	if !snapshotNameRe.MatchString(name) {
+		return "", fmt.Errorf("無效的快照名稱 %q，只允許字母、數字、'.'、'_' 和 '-'", name)
+	}
+
+	path, err := c.chainSnapshotsPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(path, name), nil
+}
+
+// chainSnapshotsPath 返回保存鏈快照的目錄。
+func (c *Chain) chainSnapshotsPath() (string, error) {
+	path, err := snapshotsPath()
+	if err != nil {
+		return "", err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(path, chainID), nil
+}
+
+// checkNotServed 在 conf 中的節點 RPC 可以訪問時返回 ErrChainServed，
+// 以免在節點寫入數據庫時讀取或重置它。
+func checkNotServed(ctx context.Context, conf chainconfig.Config) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	rpcAddr, err := xurl.HTTP(conf.Host.RPC)
+	if err != nil {
+		return err
+	}
+	if _, err := tendermintrpc.New(rpcAddr).Status(ctx); err == nil {
+		return ErrChainServed
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+)
+
+func TestCheckNotServed(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"node_info":{"network":"mars"},"sync_info":{"latest_block_height":"7"}}}`))
+	}))
+
+	var conf chainconfig.Config
+	conf.Host.RPC = rpc.URL
+	require.ErrorIs(t, checkNotServed(context.Background(), conf), ErrChainServed)
+
+	rpc.Close()
+	require.NoError(t, checkNotServed(context.Background(), conf))
+}
+
+func TestSnapshotPath(t *testing.T) {
+	cacheDir, err := os.UserCacheDir()
+	require.NoError(t, err)
+
+	c := &Chain{options: chainOptions{chainID: "mars-1"}}
+
+	path, err := c.snapshotPath("fixture")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cacheDir, "ignite", "snapshots", "mars-1", "fixture"), path)
+
+	_, err = c.snapshotPath("../fixture")
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/checksum"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosanalysis"
+	"github.com/ignite-hq/cli/ignite/pkg/protoanalysis"
+)
+
+// stateSurfaceChecksumKey 是包含可能破壞鏈狀態的源代碼部分的校驗和的緩存鍵
+const stateSurfaceChecksumKey = "state_surface_checksum"
+
+// stateSurfaceChecksum 返回源代碼中可能破壞已有鏈狀態的部分的校驗和：
+// proto 文件、創世類型、存儲鍵和鍵前綴以及模塊的共識版本。
+// 其他修改（例如 keeper 邏輯）不會改變校驗和，節點可以在已有數據上重啟。
+// 無法解析的 Go 或 proto 源文件以 CannotBuildAppError 返回，serve 會等待修復而不是退出。
+func (c *Chain) stateSurfaceChecksum(ctx context.Context, conf chainconfig.Config) (string, error) {
+	surface, err := cosmosanalysis.StateDeclarations(c.app.Path)
+	if err != nil {
+		return "", &CannotBuildAppError{err}
+	}
+
+	protoPath := filepath.Join(c.app.Path, conf.Build.Proto.Path)
+	if _, err := os.Stat(protoPath); err == nil {
+		pkgs, err := protoanalysis.Parse(ctx, nil, protoPath)
+		if err != nil {
+			return "", &CannotBuildAppError{err}
+		}
+
+		paths := pkgs.Files().Paths()
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+
+			rel, err := filepath.Rel(c.app.Path, path)
+			if err != nil {
+				return "", err
+			}
+			surface = append(surface, filepath.ToSlash(rel)+": "+string(content))
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	return checksum.Strings(surface...), nil
+}
+
+// isStateSurfaceChanged 比較源代碼中可能破壞鏈狀態的部分與上次服務時保存的校驗和。
+// 找不到已保存的校驗和時，修改被視為破壞狀態。
+func isStateSurfaceChanged(dirCache cache.Cache[[]byte], surfaceChecksum string) (bool, error) {
+	saved, err := dirCache.Get(stateSurfaceChecksumKey)
+	if err == cache.ErrorNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return string(saved) != surfaceChecksum, nil
+}
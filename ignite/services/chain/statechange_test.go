@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+)
+
+func TestStateSurfaceChecksum(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	write("x/mars/types/keys.go", "package types\n\nconst StoreKey = \"mars\"\n")
+	write("x/mars/keeper/keeper.go", "package keeper\n\nfunc Double(n int) int { return n * 2 }\n")
+	write("proto/mars/genesis.proto", "syntax = \"proto3\";\npackage mars.mars;\n\nmessage GenesisState {\n  uint64 count = 1;\n}\n")
+
+	c := &Chain{app: App{Path: dir}}
+	conf := chainconfig.Config{Build: chainconfig.Build{Proto: chainconfig.Proto{Path: "proto"}}}
+
+	initial, err := c.stateSurfaceChecksum(context.Background(), conf)
+	require.NoError(t, err)
+
+	write("x/mars/keeper/keeper.go", "package keeper\n\nfunc Double(n int) int { return n + n }\n")
+	keeperChanged, err := c.stateSurfaceChecksum(context.Background(), conf)
+	require.NoError(t, err)
+	require.Equal(t, initial, keeperChanged)
+
+	write("proto/mars/genesis.proto", "syntax = \"proto3\";\npackage mars.mars;\n\nmessage GenesisState {\n  uint64 count = 1;\n  string owner = 2;\n}\n")
+	protoChanged, err := c.stateSurfaceChecksum(context.Background(), conf)
+	require.NoError(t, err)
+	require.NotEqual(t, initial, protoChanged)
+}
+
+func TestStateSurfaceChecksumSyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	c := &Chain{app: App{Path: dir}}
+	conf := chainconfig.Config{Build: chainconfig.Build{Proto: chainconfig.Proto{Path: "proto"}}}
+
+	// serve waits for a fix on build errors, so source errors must not end it.
+	write("x/mars/types/keys.go", "package types\n\nconst StoreKey = \n")
+	_, err := c.stateSurfaceChecksum(context.Background(), conf)
+	var buildErr *CannotBuildAppError
+	require.ErrorAs(t, err, &buildErr)
+
+	write("x/mars/types/keys.go", "package types\n\nconst StoreKey = \"mars\"\n")
+	write("proto/mars/genesis.proto", "syntax = \"proto3\";\npackage mars.mars;\n\nmessage GenesisState {\n")
+	_, err = c.stateSurfaceChecksum(context.Background(), conf)
+	require.ErrorAs(t, err, &buildErr)
+}
@@ -0,0 +1,501 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/chaincmd"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite-hq/cli/ignite/pkg/xgit"
+)
+
+const (
+	// upgradeTestVotingPeriod 是升級演練中治理提案的投票期
+	upgradeTestVotingPeriod = "10s"
+
+	// upgradeTestHeightOffset 是提交提案時的高度到升級高度的區塊數，必須足夠完成投票期
+	upgradeTestHeightOffset = 20
+
+	// upgradeTestTimeout 是等待節點啟動、停止和升級後出塊的最長時間
+	upgradeTestTimeout = 5 * time.Minute
+
+	// upgradeTestTxRetries 是等待交易被打包的最大重試次數
+	upgradeTestTxRetries = 30
+)
+
+// UpgradeTestResult 是本地軟件升級演練的結果。
+type UpgradeTestResult struct {
+	// Name 是升級的名稱。
+	Name string
+
+	// FromCommit 和 ToCommit 是升級前後的二進製文件構建時的 git 提交。
+	FromCommit string
+	ToCommit   string
+
+	// ProposalID 是軟件升級治理提案的 ID。
+	ProposalID uint64
+
+	// Height 是提案中的升級高度，AppliedHeight 是新二進製文件應用升級的高度。
+	Height        int64
+	AppliedHeight int64
+
+	// Home 是演練使用的鏈主目錄，演練失敗時會保留以便檢查。
+	Home string
+}
+
+// UpgradeTest 在本地演練從 git 引用 from 到 to 的 x/upgrade 軟件升級。
+// 它構建兩個版本的二進製文件，用舊的二進製文件啟動鏈，由配置的驗證者提交並投票通過
+// 名為 name 的軟件升級提案，等待鏈在升級高度停止後換上新的二進製文件並重啟，
+// 最後確認升級處理程序和遷移在新的二進製文件中成功執行。
+func (c *Chain) UpgradeTest(ctx context.Context, cacheStorage cache.Storage, from, to, name string) (result UpgradeTestResult, err error) {
+	result.Name = name
+
+	workDir, err := os.MkdirTemp("", "ignite-upgrade-test-")
+	if err != nil {
+		return result, err
+	}
+	result.Home = filepath.Join(workDir, "home")
+
+	// 演練失敗時保留工作目錄以便檢查節點的數據和日誌。
+	defer func() {
+		if err == nil {
+			os.RemoveAll(workDir)
+		}
+	}()
+
+	// 檢出並構建兩個版本。兩個二進製文件都構建到工作目錄中，不會覆蓋 PATH 中已安裝的二進製文件。
+	fromBinDir := filepath.Join(workDir, "bin", "from")
+	toBinDir := filepath.Join(workDir, "bin", "to")
+
+	fromChain, fromCommit, err := c.upgradeTestChain(ctx, workDir, "from", from, result.Home, BinaryDir(fromBinDir))
+	if err != nil {
+		return result, err
+	}
+	result.FromCommit = fromCommit
+
+	chainID, err := fromChain.ID()
+	if err != nil {
+		return result, err
+	}
+
+	toChain, toCommit, err := c.upgradeTestChain(ctx, workDir, "to", to, result.Home, ID(chainID), BinaryDir(toBinDir))
+	if err != nil {
+		return result, err
+	}
+	result.ToCommit = toCommit
+
+	fmt.Fprintf(c.stdLog().out, "🛠️  構建升級後的二進製文件 (%s)...\n", to)
+
+	if _, err := toChain.Build(ctx, cacheStorage, toBinDir); err != nil {
+		return result, err
+	}
+
+	fmt.Fprintf(c.stdLog().out, "🛠️  構建升級前的二進製文件 (%s)...\n", from)
+
+	if _, err := fromChain.Build(ctx, cacheStorage, fromBinDir); err != nil {
+		return result, err
+	}
+
+	// 初始化鏈並縮短投票期，以便提案可以在幾個區塊內通過。
+	fmt.Fprintln(c.stdLog().out, "💿 初始化熊網鏈應用程序...")
+
+	if err := fromChain.Init(ctx, true); err != nil {
+		return result, err
+	}
+
+	conf, err := fromChain.Config()
+	if err != nil {
+		return result, err
+	}
+
+	deposit, err := fromChain.prepareUpgradeTestGenesis(conf)
+	if err != nil {
+		return result, err
+	}
+
+	nodes, err := fromChain.validatorNodes(conf)
+	if err != nil {
+		return result, err
+	}
+
+	// 用舊的二進製文件啟動鏈。
+	haltMarker := fmt.Sprintf("UPGRADE %q NEEDED", name)
+
+	oldNodes, err := fromChain.startUpgradeTestNodes(ctx, conf, nodes, haltMarker)
+	if err != nil {
+		return result, err
+	}
+	defer oldNodes.stop()
+
+	commands, err := fromChain.Commands(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	height, err := oldNodes.waitForHeight(ctx, commands, 1)
+	if err != nil {
+		return result, errors.Wrap(err, "舊的二進製文件無法啟動")
+	}
+
+	// 提交軟件升級提案並由所有驗證者投票。
+	result.Height = height + upgradeTestHeightOffset
+
+	fmt.Fprintf(c.stdLog().out, "🗳️  提交軟件升級提案 %q，升級高度 %d...\n", name, result.Height)
+
+	validators := conf.ListValidators()
+	txHash, err := commands.SubmitUpgradeProposal(ctx, validators[0].Name, name, result.Height, deposit)
+	if err != nil {
+		return result, err
+	}
+	if err := commands.WaitTx(ctx, txHash, time.Second, upgradeTestTxRetries); err != nil {
+		return result, err
+	}
+
+	if result.ProposalID, err = commands.LatestProposalID(ctx); err != nil {
+		return result, err
+	}
+
+	for _, v := range validators {
+		txHash, err := commands.Vote(ctx, v.Name, result.ProposalID, chaincmd.VoteOptionYes)
+		if err != nil {
+			return result, err
+		}
+		if err := commands.WaitTx(ctx, txHash, time.Second, upgradeTestTxRetries); err != nil {
+			return result, err
+		}
+	}
+
+	// 等待鏈在升級高度停止。
+	fmt.Fprintln(c.stdLog().out, "⏳ 等待鏈在升級高度停止...")
+
+	if err := oldNodes.waitForHalt(ctx); err != nil {
+		return result, err
+	}
+	oldNodes.stop()
+
+	// 用新的二進製文件重啟鏈。
+	fmt.Fprintln(c.stdLog().out, "🔁 換上升級後的二進製文件並重啟鏈...")
+
+	newNodes, err := toChain.startUpgradeTestNodes(ctx, conf, nodes, "")
+	if err != nil {
+		return result, err
+	}
+	defer newNodes.stop()
+
+	toCommands, err := toChain.Commands(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if _, err := newNodes.waitForHeight(ctx, toCommands, result.Height+1); err != nil {
+		return result, errors.Wrap(err, "升級後的二進製文件無法在升級高度之後出塊，升級處理程序或遷移可能失敗")
+	}
+
+	if result.AppliedHeight, err = toCommands.UpgradeAppliedHeight(ctx, name); err != nil {
+		return result, err
+	}
+	if result.AppliedHeight != result.Height {
+		return result, fmt.Errorf("升級 %q 在高度 %d 應用，預期高度為 %d", name, result.AppliedHeight, result.Height)
+	}
+
+	return result, nil
+}
+
+// upgradeTestChain 將鏈在 git 引用 ref 的源代碼檢出到工作目錄中名為 dir 的目錄，
+// 並返回使用主目錄 home 的鏈。
+func (c *Chain) upgradeTestChain(ctx context.Context, workDir, dir, ref, home string, options ...Option) (*Chain, string, error) {
+	path := filepath.Join(workDir, dir)
+
+	fmt.Fprintf(c.stdLog().out, "📥 檢出 %s...\n", ref)
+
+	commit, err := xgit.CloneAt(ctx, c.app.Path, path, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	options = append([]Option{
+		HomePath(home),
+		LogLevel(c.logLevel),
+		KeyringBackend(chaincmd.KeyringBackendTest),
+		Profile(c.Profile()),
+	}, options...)
+
+	chain, err := New(path, options...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return chain, commit, nil
+}
+
+// prepareUpgradeTestGenesis 縮短創世中治理提案的投票期並將其同步到所有驗證者節點，
+// 然後返回提交提案所需的最低押金。
+func (c *Chain) prepareUpgradeTestGenesis(conf chainconfig.Config) (deposit string, err error) {
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return "", err
+	}
+
+	err = cosmosutil.UpdateGenesisMap(genesisPath, func(genesis map[string]interface{}) error {
+		deposit, err = patchUpgradeTestGenesis(genesis)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	nodes, err := c.validatorNodes(conf)
+	if err != nil {
+		return "", err
+	}
+
+	return deposit, c.syncValidatorNodesGenesis(nodes)
+}
+
+// patchUpgradeTestGenesis 將創世中治理提案的投票期設置為 upgradeTestVotingPeriod，
+// 並返回提交提案所需的最低押金。
+func patchUpgradeTestGenesis(genesis map[string]interface{}) (deposit string, err error) {
+	gov, ok := nestedMap(genesis, "app_state", "gov")
+	if !ok {
+		return "", errors.New("鏈的創世中沒有 gov 模塊，無法提交軟件升級提案")
+	}
+
+	// 根據 SDK 的版本，治理參數保存在不同的字段中。
+	var minDeposit []interface{}
+	for _, key := range []string{"voting_params", "params"} {
+		if params, ok := nestedMap(gov, key); ok {
+			if _, ok := params["voting_period"]; ok {
+				params["voting_period"] = upgradeTestVotingPeriod
+			}
+		}
+	}
+	for _, key := range []string{"deposit_params", "params"} {
+		if params, ok := nestedMap(gov, key); ok {
+			if coins, ok := params["min_deposit"].([]interface{}); ok {
+				minDeposit = coins
+			}
+		}
+	}
+
+	var coins []string
+	for _, coin := range minDeposit {
+		if coin, ok := coin.(map[string]interface{}); ok {
+			coins = append(coins, fmt.Sprintf("%v%v", coin["amount"], coin["denom"]))
+		}
+	}
+	if len(coins) == 0 {
+		return "", errors.New("在創世中找不到治理提案的最低押金")
+	}
+
+	return strings.Join(coins, ","), nil
+}
+
+// nestedMap 返回 m 中按 keys 嵌套的映射。
+func nestedMap(m map[string]interface{}, keys ...string) (map[string]interface{}, bool) {
+	for _, key := range keys {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	return m, true
+}
+
+// upgradeTestNodes 是升級演練中運行的一組驗證者節點。
+type upgradeTestNodes struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	nodes  []*upgradeTestNode
+
+	// anyExited 在任何一個節點退出時關閉。
+	anyExited chan struct{}
+	exitOnce  sync.Once
+}
+
+// errUpgradeTestNodeExited 是節點沒有錯誤地退出時記錄的錯誤。
+var errUpgradeTestNodeExited = errors.New("節點已退出")
+
+// upgradeTestNode 是升級演練中運行的一個驗證者節點。
+type upgradeTestNode struct {
+	// watcher 在不監視停止時為 nil。
+	watcher *haltWatcher
+
+	// exited 在節點退出時關閉，之後 err 保存節點退出的錯誤。
+	exited chan struct{}
+	err    error
+}
+
+// startUpgradeTestNodes 用鏈的二進製文件啟動所有驗證者節點。
+// haltMarker 不為空時，監視節點的日誌以檢測鏈在升級高度的停止。
+func (c *Chain) startUpgradeTestNodes(ctx context.Context, conf chainconfig.Config, nodes []validatorNode, haltMarker string) (*upgradeTestNodes, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	running := &upgradeTestNodes{
+		cancel:    cancel,
+		anyExited: make(chan struct{}),
+	}
+
+	for _, n := range nodes {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			running.stop()
+			return nil, err
+		}
+
+		node := &upgradeTestNode{exited: make(chan struct{})}
+		running.nodes = append(running.nodes, node)
+
+		if haltMarker != "" {
+			node.watcher = newHaltWatcher(haltMarker)
+
+			var out io.Writer = node.watcher
+			if c.logLevel == LogVerbose {
+				out = io.MultiWriter(node.watcher, os.Stderr)
+			}
+			commands = commands.Copy(chaincmdrunner.Stdout(out), chaincmdrunner.Stderr(out))
+		}
+
+		nodeConf := conf
+		nodeConf.Host = n.host
+
+		running.wg.Add(1)
+		go func() {
+			defer running.wg.Done()
+
+			err := c.plugin.Start(ctx, commands, nodeConf)
+			if ctx.Err() == nil {
+				running.exit(node, err)
+			}
+		}()
+	}
+
+	return running, nil
+}
+
+// exit 記錄 node 以 err 退出，err 為 nil 時記錄為 errUpgradeTestNodeExited。
+func (r *upgradeTestNodes) exit(node *upgradeTestNode, err error) {
+	if err == nil {
+		err = errUpgradeTestNodeExited
+	}
+	node.err = err
+	close(node.exited)
+	r.exitOnce.Do(func() { close(r.anyExited) })
+}
+
+// exitErr 返回第一個退出的節點的錯誤。
+func (r *upgradeTestNodes) exitErr() error {
+	for _, node := range r.nodes {
+		select {
+		case <-node.exited:
+			return node.err
+		default:
+		}
+	}
+	return nil
+}
+
+// waitForHeight 等待節點達到 height 並返回節點的最新高度，任何節點提前退出時返回其錯誤。
+func (r *upgradeTestNodes) waitForHeight(ctx context.Context, commands chaincmdrunner.Runner, height int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, upgradeTestTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+
+		case <-r.anyExited:
+			return 0, r.exitErr()
+
+		case <-ticker.C:
+			// 節點啟動期間查詢狀態會失敗。
+			status, err := commands.Status(ctx)
+			if err != nil {
+				continue
+			}
+			if status.LatestBlockHeight >= height {
+				return status.LatestBlockHeight, nil
+			}
+		}
+	}
+}
+
+// waitForHalt 等待所有節點在升級高度停止。
+// 節點在停止後可能會退出，因此節點退出時先檢查它是否已經停止。
+func (r *upgradeTestNodes) waitForHalt(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, upgradeTestTimeout)
+	defer cancel()
+
+	for _, node := range r.nodes {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "鏈沒有在升級高度停止，提案可能沒有通過")
+
+		case <-node.watcher.halted:
+
+		case <-node.exited:
+			select {
+			case <-node.watcher.halted:
+			default:
+				return errors.Wrap(node.err, "節點在升級高度之前退出")
+			}
+		}
+	}
+
+	return nil
+}
+
+// stop 停止所有節點並等待它們退出。
+func (r *upgradeTestNodes) stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// haltWatcher 監視節點的日誌，在日誌中出現 marker 時關閉 halted。
+type haltWatcher struct {
+	marker string
+	halted chan struct{}
+	once   sync.Once
+
+	mu   sync.Mutex
+	tail []byte
+}
+
+func newHaltWatcher(marker string) *haltWatcher {
+	return &haltWatcher{
+		marker: marker,
+		halted: make(chan struct{}),
+	}
+}
+
+func (w *haltWatcher) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// 保留上一次寫入的尾部，以便檢測跨越兩次寫入的 marker。
+	data := append(w.tail, p...)
+	if strings.Contains(string(data), w.marker) {
+		w.once.Do(func() { close(w.halted) })
+	}
+
+	if keep := len(w.marker); len(data) > keep {
+		data = data[len(data)-keep:]
+	}
+	w.tail = append([]byte(nil), data...)
+
+	return len(p), nil
+}
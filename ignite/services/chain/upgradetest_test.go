@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUpgradeTestGenesis(t *testing.T) {
+	var genesis map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "chain_id": "mars",
+  "app_state": {
+    "gov": {
+      "deposit_params": {
+        "min_deposit": [{"denom": "stake", "amount": "10000000"}],
+        "max_deposit_period": "172800s"
+      },
+      "voting_params": {"voting_period": "172800s"}
+    }
+  }
+}`), &genesis))
+
+	deposit, err := patchUpgradeTestGenesis(genesis)
+	require.NoError(t, err)
+	require.Equal(t, "10000000stake", deposit)
+
+	params, ok := nestedMap(genesis, "app_state", "gov", "voting_params")
+	require.True(t, ok)
+	require.Equal(t, upgradeTestVotingPeriod, params["voting_period"])
+	require.Equal(t, "mars", genesis["chain_id"])
+
+	_, err = patchUpgradeTestGenesis(map[string]interface{}{"app_state": map[string]interface{}{}})
+	require.Error(t, err)
+}
+
+func TestHaltWatcher(t *testing.T) {
+	w := newHaltWatcher(fmt.Sprintf("UPGRADE %q NEEDED", "v2"))
+
+	_, err := w.Write([]byte("INF committed state height=24\nERR UPGRADE \"v"))
+	require.NoError(t, err)
+
+	select {
+	case <-w.halted:
+		t.Fatal("halted before the marker was written")
+	default:
+	}
+
+	_, err = w.Write([]byte("2\" NEEDED at height: 25: \n"))
+	require.NoError(t, err)
+
+	select {
+	case <-w.halted:
+	default:
+		t.Fatal("marker split across writes was not detected")
+	}
+}
+
+// newTestUpgradeNodes returns n nodes watching for the halt marker, without running them.
+func newTestUpgradeNodes(n int) *upgradeTestNodes {
+	r := &upgradeTestNodes{
+		cancel:    func() {},
+		anyExited: make(chan struct{}),
+	}
+	for i := 0; i < n; i++ {
+		r.nodes = append(r.nodes, &upgradeTestNode{
+			watcher: newHaltWatcher("UPGRADE NEEDED"),
+			exited:  make(chan struct{}),
+		})
+	}
+	return r
+}
+
+func TestUpgradeTestNodesWaitForHalt(t *testing.T) {
+	// the nodes can exit right after printing the halt marker.
+	for i := 0; i < 100; i++ {
+		r := newTestUpgradeNodes(2)
+		for _, node := range r.nodes {
+			_, err := node.watcher.Write([]byte("ERR UPGRADE NEEDED at height: 25\n"))
+			require.NoError(t, err)
+			r.exit(node, errors.New("exit status 1"))
+		}
+		require.NoError(t, r.waitForHalt(context.Background()))
+	}
+
+	// another node exiting after its halt doesn't fail the wait for the others.
+	r := newTestUpgradeNodes(2)
+	go func() {
+		r.nodes[1].watcher.Write([]byte("UPGRADE NEEDED"))
+		r.exit(r.nodes[1], nil)
+		r.nodes[0].watcher.Write([]byte("UPGRADE NEEDED"))
+	}()
+	require.NoError(t, r.waitForHalt(context.Background()))
+
+	// a node exiting before the halt fails.
+	r = newTestUpgradeNodes(2)
+	r.nodes[0].watcher.Write([]byte("UPGRADE NEEDED"))
+	r.exit(r.nodes[1], nil)
+	err := r.waitForHalt(context.Background())
+	require.ErrorIs(t, err, errUpgradeTestNodeExited)
+}
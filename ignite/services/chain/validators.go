@@ -0,0 +1,320 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/otiai10/copy"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/availableport"
+	chaincmdrunner "github.com/ignite-hq/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+)
+
+const (
+	// nodeHostFile 是保存驗證者節點已分配主機地址的文件名，位於節點的 config 目錄中。
+	nodeHostFile = "ignite_host.json"
+
+	// nodePortCount 是每個驗證者節點需要分配的端口數量。
+	nodePortCount = 6
+)
+
+// validatorNode 代表本地測試網中的一個驗證者節點。
+type validatorNode struct {
+	validator chainconfig.Validator
+	moniker   string
+	home      string
+	host      chainconfig.Host
+}
+
+// validatorNodes 返回鏈的所有驗證者節點，第一個節點為主節點。
+// 主節點使用鏈的主目錄和 config.yml 中的主機配置，
+// 其他節點使用各自的主目錄和初始化時分配的端口。
+func (c *Chain) validatorNodes(conf chainconfig.Config) ([]validatorNode, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	validators := conf.ListValidators()
+	nodes := []validatorNode{
+		{
+			validator: validators[0],
+			moniker:   moniker,
+			home:      home,
+			host:      conf.Host,
+		},
+	}
+
+	for _, v := range validators[1:] {
+		n := validatorNode{
+			validator: v,
+			moniker:   v.Name,
+			home:      validatorNodeHome(home, v.Name),
+		}
+
+		cf := confile.New(confile.DefaultJSONEncodingCreator, filepath.Join(n.home, "config", nodeHostFile))
+		if err := cf.Load(&n.host); err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	return nodes, nil
+}
+
+// validatorNodeHome 返回驗證者節點的主目錄。
+func validatorNodeHome(home, name string) string {
+	return fmt.Sprintf("%s-%s", home, name)
+}
+
+// nodeCommands 返回在驗證者節點上執行命令的運行者。
+func (c *Chain) nodeCommands(ctx context.Context, n validatorNode) (chaincmdrunner.Runner, error) {
+	return c.commands(ctx, n.home, n.host.RPC)
+}
+
+// initValidatorNodes 初始化主節點以外的驗證者節點，
+// 為每個節點分配可用端口並應用 config.yml 中的配置。
+func (c *Chain) initValidatorNodes(ctx context.Context, conf chainconfig.Config) error {
+	if len(conf.Validators) < 2 {
+		return nil
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return err
+	}
+
+	// 一次分配所有節點的端口，以免不同節點得到相同的端口。
+	validators := conf.Validators[1:]
+	allPorts, err := availableport.Find(nodePortCount * len(validators))
+	if err != nil {
+		return err
+	}
+
+	for i, v := range validators {
+		ports := allPorts[i*nodePortCount : (i+1)*nodePortCount]
+
+		n := validatorNode{
+			validator: v,
+			moniker:   v.Name,
+			home:      validatorNodeHome(home, v.Name),
+			host: chainconfig.Host{
+				RPC:     fmt.Sprintf("0.0.0.0:%d", ports[0]),
+				P2P:     fmt.Sprintf("0.0.0.0:%d", ports[1]),
+				Prof:    fmt.Sprintf("0.0.0.0:%d", ports[2]),
+				GRPC:    fmt.Sprintf("0.0.0.0:%d", ports[3]),
+				GRPCWeb: fmt.Sprintf("0.0.0.0:%d", ports[4]),
+				API:     fmt.Sprintf("0.0.0.0:%d", ports[5]),
+			},
+		}
+
+		if err := os.RemoveAll(n.home); err != nil {
+			return err
+		}
+
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.Init(ctx, n.moniker); err != nil {
+			return err
+		}
+
+		nodeConf := conf
+		nodeConf.Host = n.host
+		if err := c.plugin.Configure(n.home, nodeConf); err != nil {
+			return err
+		}
+
+		if err := overrideConfigs(n.home, conf); err != nil {
+			return err
+		}
+
+		cf := confile.New(confile.DefaultJSONEncodingCreator, filepath.Join(n.home, "config", nodeHostFile))
+		if err := cf.Save(n.host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// issueValidatorNodesGentxs 為每個驗證者節點生成 gentx，將它們收集到主節點的創世中，
+// 然後把最終的創世分發到所有節點並連接節點之間的 P2P 網絡。
+func (c *Chain) issueValidatorNodesGentxs(ctx context.Context, conf chainconfig.Config, mnemonics map[string]string) error {
+	nodes, err := c.validatorNodes(conf)
+	if err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	gentxsPath, err := c.GentxsPath()
+	if err != nil {
+		return err
+	}
+
+	for i, n := range nodes {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		// 驗證者的密鑰必須存在於節點自己的密鑰環中才能簽署 gentx。
+		if i > 0 {
+			mnemonic, ok := mnemonics[n.validator.Name]
+			if !ok {
+				return fmt.Errorf("驗證者 %q 必須是 accounts 中未指定地址的帳戶", n.validator.Name)
+			}
+
+			account, _ := conf.AccountByName(n.validator.Name)
+			if _, err := commands.AddAccount(ctx, account.Name, mnemonic, account.CoinType); err != nil {
+				return err
+			}
+
+			if err := copy.Copy(genesisPath, nodeGenesisPath(n.home)); err != nil {
+				return err
+			}
+		}
+
+		gentxPath, err := c.plugin.Gentx(ctx, commands, Validator{
+			Name:          n.validator.Name,
+			Moniker:       n.moniker,
+			StakingAmount: n.validator.Staked,
+		})
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if err := copy.Copy(gentxPath, filepath.Join(gentxsPath, filepath.Base(gentxPath))); err != nil {
+				return err
+			}
+		}
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.CollectGentxs(ctx); err != nil {
+		return err
+	}
+
+	if err := c.syncValidatorNodesGenesis(nodes); err != nil {
+		return err
+	}
+
+	return c.connectValidatorNodes(ctx, nodes)
+}
+
+// syncValidatorNodesGenesis 將主節點的創世複製到其他驗證者節點。
+func (c *Chain) syncValidatorNodesGenesis(nodes []validatorNode) error {
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes[1:] {
+		if err := copy.Copy(genesisPath, nodeGenesisPath(n.home)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetValidatorNodes 重置主節點以外的驗證者節點的數據庫並導入主節點的創世。
+func (c *Chain) resetValidatorNodes(ctx context.Context, conf chainconfig.Config) error {
+	nodes, err := c.validatorNodes(conf)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes[1:] {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		if err := commands.UnsafeReset(ctx); err != nil {
+			return err
+		}
+	}
+
+	return c.syncValidatorNodesGenesis(nodes)
+}
+
+// connectValidatorNodes 將每個驗證者節點的其他節點設置為持久對等節點。
+func (c *Chain) connectValidatorNodes(ctx context.Context, nodes []validatorNode) error {
+	peers := make([]string, len(nodes))
+	for i, n := range nodes {
+		commands, err := c.nodeCommands(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		nodeID, err := commands.ShowNodeID(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, port, err := net.SplitHostPort(n.host.P2P)
+		if err != nil {
+			return errors.Wrapf(err, "無效的 p2p 地址格式 %s", n.host.P2P)
+		}
+
+		peers[i] = fmt.Sprintf("%s@127.0.0.1:%s", nodeID, port)
+	}
+
+	for i, n := range nodes {
+		var nodePeers []string
+		for j, peer := range peers {
+			if i != j {
+				nodePeers = append(nodePeers, peer)
+			}
+		}
+
+		path := nodeConfigTOMLPath(n.home)
+		config, err := toml.LoadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// 所有節點都在本機上運行，因此需要允許相同 IP 的多個對等節點。
+		config.Set("p2p.persistent_peers", strings.Join(nodePeers, ","))
+		config.Set("p2p.allow_duplicate_ip", true)
+		config.Set("p2p.addr_book_strict", false)
+
+		if err := saveTOML(path, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func saveTOML(path string, config *toml.Tree) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = config.WriteTo(file)
+	return err
+}
@@ -0,0 +1,206 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+)
+
+// fakeChainBinary is a chain binary that implements the commands used to set up
+// the validator nodes: it writes minimal config files on init, a gentx named after
+// the validator on gentx, merges the gentxs into the genesis on collect-gentxs and
+// derives the node id from the home directory.
+const fakeChainBinary = `#!/bin/sh
+home=""
+prev=""
+for arg in "$@"; do
+	if [ "$prev" = "--home" ]; then home="$arg"; fi
+	prev="$arg"
+done
+
+case "$1" in
+init)
+	mkdir -p "$home/config"
+	echo '{"app_state":{"genutil":{"gen_txs":[]}}}' > "$home/config/genesis.json"
+	: > "$home/config/app.toml"
+	: > "$home/config/config.toml"
+	: > "$home/config/client.toml"
+	;;
+keys)
+	case "$2" in
+	list) echo '[]' ;;
+	add) cat > /dev/null ;;
+	show) echo "cosmos1$3" ;;
+	esac
+	;;
+gentx)
+	mkdir -p "$home/config/gentx"
+	path="$home/config/gentx/gentx-$2.json"
+	echo "{\"validator\":\"$2\"}" > "$path"
+	echo "Genesis transaction written to \"$path\""
+	;;
+collect-gentxs)
+	gentxs=$(cat "$home"/config/gentx/*.json | paste -sd, -)
+	echo "{\"app_state\":{\"genutil\":{\"gen_txs\":[$gentxs]}}}" > "$home/config/genesis.json"
+	;;
+tendermint)
+	echo "id-$(basename "$home")"
+	;;
+esac
+`
+
+func newTestValidatorsChain(t *testing.T) (*Chain, chainconfig.Config) {
+	binDir := t.TempDir()
+	home := filepath.Join(t.TempDir(), "mars")
+
+	c, err := New(
+		tempSource(t, "testdata/version/mars.v0.2.tar.gz"),
+		BinaryDir(binDir),
+		HomePath(home),
+		ConfigOverride(func(conf *chainconfig.Config) {
+			conf.Accounts = append(conf.Accounts, chainconfig.Account{Name: "carol", Coins: []string{"100000000stake"}})
+			conf.Validator = chainconfig.Validator{}
+			conf.Validators = []chainconfig.Validator{
+				{Name: "alice", Staked: "100000000stake"},
+				{Name: "bob", Staked: "100000000stake"},
+				{Name: "carol", Staked: "100000000stake"},
+			}
+		}),
+	)
+	require.NoError(t, err)
+
+	binary, err := c.Binary()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, binary), []byte(fakeChainBinary), 0755))
+
+	conf, err := c.Config()
+	require.NoError(t, err)
+
+	commands, err := c.Commands(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, commands.Init(context.Background(), moniker))
+
+	return c, conf
+}
+
+func TestInitValidatorNodes(t *testing.T) {
+	c, conf := newTestValidatorsChain(t)
+	require.NoError(t, c.initValidatorNodes(context.Background(), conf))
+
+	home, err := c.Home()
+	require.NoError(t, err)
+
+	ports := make(map[string]bool)
+	for _, name := range []string{"bob", "carol"} {
+		nodeHome := validatorNodeHome(home, name)
+
+		var host chainconfig.Host
+		cf := confile.New(confile.DefaultJSONEncodingCreator, filepath.Join(nodeHome, "config", nodeHostFile))
+		require.NoError(t, cf.Load(&host))
+
+		// every address of every node gets its own port.
+		for _, addr := range []string{host.RPC, host.P2P, host.Prof, host.GRPC, host.GRPCWeb, host.API} {
+			_, port, err := net.SplitHostPort(addr)
+			require.NoError(t, err)
+			require.False(t, ports[port], "port %s is assigned twice", port)
+			ports[port] = true
+		}
+
+		// the node's configs listen on the assigned ports.
+		config, err := toml.LoadFile(nodeConfigTOMLPath(nodeHome))
+		require.NoError(t, err)
+		require.Equal(t, "tcp://"+host.RPC, config.Get("rpc.laddr"))
+		require.Equal(t, "tcp://"+host.P2P, config.Get("p2p.laddr"))
+		require.Equal(t, host.Prof, config.Get("rpc.pprof_laddr"))
+
+		app, err := toml.LoadFile(nodeAppTOMLPath(nodeHome))
+		require.NoError(t, err)
+		require.Equal(t, "tcp://"+host.API, app.Get("api.address"))
+		require.Equal(t, host.GRPC, app.Get("grpc.address"))
+		require.Equal(t, host.GRPCWeb, app.Get("grpc-web.address"))
+	}
+	require.Len(t, ports, 2*nodePortCount)
+}
+
+func TestIssueValidatorNodesGentxs(t *testing.T) {
+	c, conf := newTestValidatorsChain(t)
+	ctx := context.Background()
+	require.NoError(t, c.initValidatorNodes(ctx, conf))
+
+	// validators other than the main one must have a mnemonic.
+	err := c.issueValidatorNodesGentxs(ctx, conf, map[string]string{"bob": "bob mnemonic"})
+	require.Error(t, err)
+
+	mnemonics := map[string]string{
+		"bob":   "bob mnemonic",
+		"carol": "carol mnemonic",
+	}
+	require.NoError(t, c.issueValidatorNodesGentxs(ctx, conf, mnemonics))
+
+	nodes, err := c.validatorNodes(conf)
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	// the genesis of every node is the main genesis, which holds the gentxs of all validators.
+	genesisPath, err := c.GenesisPath()
+	require.NoError(t, err)
+	genesis, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+
+	var state struct {
+		AppState struct {
+			Genutil struct {
+				GenTxs []struct {
+					Validator string `json:"validator"`
+				} `json:"gen_txs"`
+			} `json:"genutil"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(genesis, &state))
+
+	var validators []string
+	for _, gentx := range state.AppState.Genutil.GenTxs {
+		validators = append(validators, gentx.Validator)
+	}
+	require.ElementsMatch(t, []string{"alice", "bob", "carol"}, validators)
+
+	for _, n := range nodes[1:] {
+		nodeGenesis, err := os.ReadFile(nodeGenesisPath(n.home))
+		require.NoError(t, err)
+		require.Equal(t, string(genesis), string(nodeGenesis))
+	}
+
+	// every node has all the other nodes as persistent peers.
+	peers := make([]string, len(nodes))
+	for i, n := range nodes {
+		_, port, err := net.SplitHostPort(n.host.P2P)
+		require.NoError(t, err)
+		peers[i] = fmt.Sprintf("id-%s@127.0.0.1:%s", filepath.Base(n.home), port)
+	}
+
+	for i, n := range nodes {
+		var want []string
+		for j, peer := range peers {
+			if i != j {
+				want = append(want, peer)
+			}
+		}
+
+		config, err := toml.LoadFile(nodeConfigTOMLPath(n.home))
+		require.NoError(t, err)
+		require.Equal(t, strings.Join(want, ","), config.Get("p2p.persistent_peers"))
+		require.Equal(t, true, config.Get("p2p.allow_duplicate_ip"))
+		require.Equal(t, false, config.Get("p2p.addr_book_strict"))
+	}
+}
@@ -0,0 +1,194 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite-hq/cli/ignite/pkg/relayer"
+)
+
+const (
+	// DefaultFileName 是工作區文件的默認名稱。
+	DefaultFileName = "workspace.yml"
+
+	// defaultPortOffset 是相鄰兩條鏈的端口之間的默認間隔。
+	defaultPortOffset = 10
+)
+
+// Config 是列出工作區中的鏈和它們之間的 IBC 路徑的工作區文件。
+type Config struct {
+	// Chains 是工作區中的鏈，第 n 條鏈（從 0 開始）的端口在其配置的基礎上增加 n*port_offset。
+	Chains []Chain `yaml:"chains"`
+
+	// Paths 是要連接的鏈，為空時連接每一對鏈。
+	Paths []Path `yaml:"paths"`
+
+	// Relayer 配置在鏈之間中繼數據包的帳戶。
+	Relayer Relayer `yaml:"relayer"`
+
+	// PortOffset 是相鄰兩條鏈的端口之間的間隔，默認為 10。
+	PortOffset int `yaml:"port_offset"`
+}
+
+// Chain 是工作區中的一條鏈。
+type Chain struct {
+	// Name 是鏈在工作區中的名稱，用於路徑和日誌，默認為鏈目錄的名稱。
+	Name string `yaml:"name"`
+
+	// Path 是相對於工作區文件的鏈項目目錄。
+	Path string `yaml:"path"`
+
+	// Config 是相對於鏈項目目錄的自定義配置文件。
+	Config string `yaml:"config"`
+
+	// Home 是鏈的主目錄。
+	Home string `yaml:"home"`
+
+	// GasPrice 是中繼器在鏈上發送交易使用的 Gas 價格，默認為 0 個質押代幣。
+	GasPrice string `yaml:"gas_price"`
+
+	// RelayerFunds 是中繼器帳戶餘額為空時從鏈的第一個驗證者帳戶轉入的代幣，默認為 1000000 個質押代幣。
+	RelayerFunds string `yaml:"relayer_funds"`
+}
+
+// Path 是兩條鏈之間的 IBC 路徑。
+type Path struct {
+	// Src 和 Dst 是鏈在工作區中的名稱。
+	Src string `yaml:"src"`
+	Dst string `yaml:"dst"`
+
+	SrcPort    string `yaml:"src_port"`
+	SrcVersion string `yaml:"src_version"`
+	DstPort    string `yaml:"dst_port"`
+	DstVersion string `yaml:"dst_version"`
+	Ordered    bool   `yaml:"ordered"`
+}
+
+// Relayer 配置中繼器。
+type Relayer struct {
+	// Account 是 Ignite 帳戶中用於中繼的帳戶，默認為 default。
+	Account string `yaml:"account"`
+}
+
+// Parse 解析工作區文件並應用默認值，root 是鏈路徑的基礎目錄。
+func Parse(r io.Reader, root string) (Config, error) {
+	var conf Config
+	if err := yaml.NewDecoder(r).Decode(&conf); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Config{}, &ValidationError{"工作區中至少需要 2 條鏈"}
+		}
+		return Config{}, err
+	}
+
+	for i, c := range conf.Chains {
+		if c.Path == "" {
+			return Config{}, &ValidationError{fmt.Sprintf("第 %d 條鏈缺少 path", i+1)}
+		}
+		if !filepath.IsAbs(c.Path) {
+			conf.Chains[i].Path = filepath.Join(root, c.Path)
+		}
+		if c.Name == "" {
+			conf.Chains[i].Name = filepath.Base(conf.Chains[i].Path)
+		}
+	}
+	if conf.PortOffset == 0 {
+		conf.PortOffset = defaultPortOffset
+	}
+	if conf.Relayer.Account == "" {
+		conf.Relayer.Account = cosmosaccount.DefaultAccount
+	}
+	if len(conf.Paths) == 0 {
+		for i := range conf.Chains {
+			for _, dst := range conf.Chains[i+1:] {
+				conf.Paths = append(conf.Paths, Path{Src: conf.Chains[i].Name, Dst: dst.Name})
+			}
+		}
+	}
+	for i, p := range conf.Paths {
+		if p.SrcPort == "" {
+			conf.Paths[i].SrcPort = relayer.TransferPort
+		}
+		if p.SrcVersion == "" {
+			conf.Paths[i].SrcVersion = relayer.TransferVersion
+		}
+		if p.DstPort == "" {
+			conf.Paths[i].DstPort = relayer.TransferPort
+		}
+		if p.DstVersion == "" {
+			conf.Paths[i].DstVersion = relayer.TransferVersion
+		}
+	}
+
+	return conf, validate(conf)
+}
+
+// ParseFile 解析位於 path 的工作區文件，鏈的路徑相對於工作區文件所在的目錄。
+func ParseFile(path string) (Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer file.Close()
+
+	root, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return Config{}, err
+	}
+	return Parse(file, root)
+}
+
+// ChainByName 按名稱查找鏈。
+func (c Config) ChainByName(name string) (chain Chain, found bool) {
+	for _, chain := range c.Chains {
+		if chain.Name == name {
+			return chain, true
+		}
+	}
+	return Chain{}, false
+}
+
+// validate 驗證工作區文件。
+func validate(conf Config) error {
+	if len(conf.Chains) < 2 {
+		return &ValidationError{"工作區中至少需要 2 條鏈"}
+	}
+	if conf.PortOffset < 0 {
+		return &ValidationError{"port_offset 不能為負數"}
+	}
+
+	names := make(map[string]bool)
+	for _, c := range conf.Chains {
+		if names[c.Name] {
+			return &ValidationError{fmt.Sprintf("鏈 %q 被定義了多次", c.Name)}
+		}
+		names[c.Name] = true
+	}
+
+	for _, p := range conf.Paths {
+		for _, name := range []string{p.Src, p.Dst} {
+			if !names[name] {
+				return &ValidationError{fmt.Sprintf("路徑 %s-%s 引用了不存在的鏈 %q", p.Src, p.Dst, name)}
+			}
+		}
+		if p.Src == p.Dst {
+			return &ValidationError{fmt.Sprintf("路徑不能連接鏈 %q 到自身", p.Src)}
+		}
+	}
+
+	return nil
+}
+
+// ValidationError 在工作區文件無效時返回。
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("工作區文件無效: %s", e.Message)
+}
@@ -0,0 +1,104 @@
+package workspace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	conf, err := Parse(strings.NewReader(`
+chains:
+  - path: mars
+  - path: ./chains/venus
+    name: venus
+    gas_price: 0.025uvenus
+  - path: /abs/earth
+`), "/workspace")
+	require.NoError(t, err)
+
+	require.Equal(t, []Chain{
+		{Name: "mars", Path: "/workspace/mars"},
+		{Name: "venus", Path: "/workspace/chains/venus", GasPrice: "0.025uvenus"},
+		{Name: "earth", Path: "/abs/earth"},
+	}, conf.Chains)
+	require.Equal(t, 10, conf.PortOffset)
+	require.Equal(t, "default", conf.Relayer.Account)
+
+	transfer := func(src, dst string) Path {
+		return Path{Src: src, Dst: dst, SrcPort: "transfer", SrcVersion: "ics20-1", DstPort: "transfer", DstVersion: "ics20-1"}
+	}
+	require.Equal(t, []Path{
+		transfer("mars", "venus"),
+		transfer("mars", "earth"),
+		transfer("venus", "earth"),
+	}, conf.Paths)
+
+	conf, err = Parse(strings.NewReader(`
+chains:
+  - path: mars
+  - path: venus
+paths:
+  - src: venus
+    dst: mars
+    src_port: blog
+    dst_port: blog
+    src_version: blog-1
+    dst_version: blog-1
+    ordered: true
+relayer:
+  account: relayer
+port_offset: 100
+`), "/workspace")
+	require.NoError(t, err)
+	require.Equal(t, []Path{
+		{Src: "venus", Dst: "mars", SrcPort: "blog", SrcVersion: "blog-1", DstPort: "blog", DstVersion: "blog-1", Ordered: true},
+	}, conf.Paths)
+	require.Equal(t, 100, conf.PortOffset)
+	require.Equal(t, "relayer", conf.Relayer.Account)
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := []struct {
+		name, workspace, err string
+	}{
+		{
+			name:      "empty",
+			workspace: ``,
+			err:       "工作區文件無效: 工作區中至少需要 2 條鏈",
+		},
+		{
+			name:      "one chain",
+			workspace: "chains: [{path: mars}]",
+			err:       "工作區文件無效: 工作區中至少需要 2 條鏈",
+		},
+		{
+			name:      "missing path",
+			workspace: "chains: [{path: mars}, {name: venus}]",
+			err:       "工作區文件無效: 第 2 條鏈缺少 path",
+		},
+		{
+			name:      "duplicated name",
+			workspace: "chains: [{path: mars}, {path: other/mars}]",
+			err:       `工作區文件無效: 鏈 "mars" 被定義了多次`,
+		},
+		{
+			name:      "unknown chain in path",
+			workspace: "chains: [{path: mars}, {path: venus}]\npaths: [{src: mars, dst: earth}]",
+			err:       `工作區文件無效: 路徑 mars-earth 引用了不存在的鏈 "earth"`,
+		},
+		{
+			name:      "path to itself",
+			workspace: "chains: [{path: mars}, {path: venus}]\npaths: [{src: mars, dst: mars}]",
+			err:       `工作區文件無效: 路徑不能連接鏈 "mars" 到自身`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.workspace), "/workspace")
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ignite-hq/cli/ignite/pkg/checksum"
+	"github.com/ignite-hq/cli/ignite/pkg/confile"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite-hq/cli/ignite/pkg/relayer"
+	relayerconfig "github.com/ignite-hq/cli/ignite/pkg/relayer/config"
+	"github.com/ignite-hq/cli/ignite/pkg/xurl"
+)
+
+const (
+	// stateFile 是工作區數據目錄中保存已創建路徑的文件。
+	stateFile = "state.yml"
+
+	// relayerGasLimit 是中繼器在鏈上發送交易使用的 Gas 限制。
+	relayerGasLimit = 300000
+
+	// defaultRelayerFunds 是默認轉入中繼器帳戶的質押代幣數量。
+	defaultRelayerFunds = 1000000
+
+	// relayRetryDelay 是中繼出錯後重試前等待的時間。
+	relayRetryDelay = 5 * time.Second
+
+	// chainCheckInterval 是檢查鏈是否啟動以及狀態是否被重置的間隔。
+	chainCheckInterval = time.Second
+)
+
+// errChainsReset 在中繼期間有鏈的狀態被重置時返回。
+var errChainsReset = errors.New("鏈的狀態已重置")
+
+// state 保存工作區在多次服務之間的狀態。
+type state struct {
+	// Paths 將中繼器路徑 ID 映射到創建路徑時兩條鏈的創世校驗和。
+	// 校驗和改變表示鏈的狀態已被重置，路徑需要重新創建。
+	Paths map[string]string `yaml:"paths"`
+}
+
+// relay 在所有鏈啟動後連接路徑並中繼數據包，直到 ctx 被取消。
+// 中繼出錯或有鏈的狀態被重置時，等待所有鏈重新啟動後重新連接。
+func (w *Workspace) relay(ctx context.Context) error {
+	for {
+		err := w.relayOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if errors.Is(err, errChainsReset) {
+			fmt.Fprintln(w.out, "🔄 鏈的狀態已重置，重新連接 IBC 路徑...")
+			continue
+		}
+		fmt.Fprintf(w.out, "⚠️  中繼器出錯，%s 後重試: %s\n", relayRetryDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(relayRetryDelay):
+		}
+	}
+}
+
+func (w *Workspace) relayOnce(ctx context.Context) error {
+	fmt.Fprintln(w.out, "⏳ 等待工作區中的所有鏈啟動...")
+	if err := w.waitChains(ctx); err != nil {
+		return err
+	}
+
+	sums, err := w.genesisChecksums()
+	if err != nil {
+		return err
+	}
+
+	ids, err := w.link(ctx, sums)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out, "📡 在鏈之間監聽及中繼數據包...")
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return relayer.New(w.ca).Start(ctx, ids...)
+	})
+	g.Go(func() error {
+		return w.watchReset(ctx, sums)
+	})
+	return g.Wait()
+}
+
+// waitChains 等待所有鏈出塊。
+func (w *Workspace) waitChains(ctx context.Context) error {
+	for _, wc := range w.chains {
+		for {
+			// 鏈在構建或初始化期間命令會失敗，繼續等待
+			if commands, err := wc.chain.Commands(ctx); err == nil {
+				if status, err := commands.Status(ctx); err == nil && status.LatestBlockHeight > 0 {
+					break
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(chainCheckInterval):
+			}
+		}
+	}
+	return nil
+}
+
+// genesisChecksums 返回每條鏈的創世校驗和，以鏈名稱為鍵。
+func (w *Workspace) genesisChecksums() (map[string]string, error) {
+	sums := make(map[string]string)
+	for _, wc := range w.chains {
+		genesisPath, err := wc.chain.GenesisPath()
+		if err != nil {
+			return nil, err
+		}
+		if sums[wc.Name], err = checksum.File(genesisPath); err != nil {
+			return nil, err
+		}
+	}
+	return sums, nil
+}
+
+// watchReset 在任何一條鏈的創世與 sums 不同時返回 errChainsReset。
+func (w *Workspace) watchReset(ctx context.Context, sums map[string]string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(chainCheckInterval):
+		}
+
+		current, err := w.genesisChecksums()
+		if err != nil {
+			// 鏈重置期間創世可能暫時不存在
+			continue
+		}
+		for name, sum := range sums {
+			if current[name] != sum {
+				return errChainsReset
+			}
+		}
+	}
+}
+
+// link 在中繼器中配置工作區的鏈和路徑並創建 IBC 連接，返回路徑的 ID。
+// 與上次創建時相比狀態沒有被重置的鏈之間的路徑被保留，否則重新創建。
+func (w *Workspace) link(ctx context.Context, sums map[string]string) ([]string, error) {
+	r := relayer.New(w.ca)
+
+	chains := make(map[string]*relayer.Chain)
+	for _, wc := range w.chains {
+		c, err := w.setupRelayerChain(ctx, r, wc)
+		if err != nil {
+			return nil, fmt.Errorf("鏈 %q: %w", wc.Name, err)
+		}
+		chains[wc.Name] = c
+	}
+
+	stateConf := confile.New(confile.DefaultYAMLEncodingCreator, filepath.Join(w.dataPath, stateFile))
+	var s state
+	if err := stateConf.Load(&s); err != nil {
+		return nil, err
+	}
+	if s.Paths == nil {
+		s.Paths = make(map[string]string)
+	}
+
+	var ids []string
+	for _, p := range w.conf.Paths {
+		src, dst := chains[p.Src], chains[p.Dst]
+		id := fmt.Sprintf("%s-%s", src.ID, dst.ID)
+		sum := checksum.Strings(sums[p.Src], sums[p.Dst])
+
+		conf, err := relayerconfig.Get()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conf.PathByID(id); err == nil && s.Paths[id] == sum {
+			ids = append(ids, id)
+			continue
+		}
+
+		if err := removeRelayerPath(id); err != nil {
+			return nil, err
+		}
+
+		options := []relayer.ChannelOption{
+			relayer.SourcePort(p.SrcPort),
+			relayer.SourceVersion(p.SrcVersion),
+			relayer.TargetPort(p.DstPort),
+			relayer.TargetVersion(p.DstVersion),
+		}
+		if p.Ordered {
+			options = append(options, relayer.Ordered())
+		}
+		if id, err = src.Connect(dst, options...); err != nil {
+			return nil, err
+		}
+
+		s.Paths[id] = sum
+		if err := stateConf.Save(s); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	fmt.Fprintln(w.out, "🔗 在鏈之間創建 IBC 連接...")
+	if err := r.Link(ctx, ids...); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		path, err := r.GetPath(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(
+			w.out,
+			"⛓  %s: %s (%s/%s) <> %s (%s/%s)\n",
+			path.ID,
+			path.Src.ChainID, path.Src.PortID, path.Src.ChannelID,
+			path.Dst.ChainID, path.Dst.PortID, path.Dst.ChannelID,
+		)
+	}
+
+	return ids, nil
+}
+
+// setupRelayerChain 在中繼器中配置鏈，並在中繼器帳戶沒有餘額時從鏈的第一個驗證者帳戶轉入代幣。
+func (w *Workspace) setupRelayerChain(ctx context.Context, r relayer.Relayer, wc workspaceChain) (*relayer.Chain, error) {
+	conf, err := wc.chain.Config()
+	if err != nil {
+		return nil, err
+	}
+	commands, err := wc.chain.Commands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := conf.ListValidators()[0]
+	staked, err := sdk.ParseCoinNormalized(validator.Staked)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorAccount, err := commands.ShowAccount(ctx, validator.Name)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := cosmosutil.GetAddressPrefix(validatorAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice := wc.GasPrice
+	if gasPrice == "" {
+		gasPrice = sdk.NewInt64Coin(staked.Denom, 0).String()
+	}
+
+	rpcAddress, err := xurl.HTTP(conf.Host.RPC)
+	if err != nil {
+		return nil, err
+	}
+
+	// 鏈的 RPC 地址改變後（例如修改了 port_offset），刪除中繼器中的舊配置
+	if err := removeRelayerChain(wc.id, rpcAddress); err != nil {
+		return nil, err
+	}
+
+	c, account, err := r.NewChain(
+		ctx,
+		w.conf.Relayer.Account,
+		rpcAddress,
+		relayer.WithGasPrice(gasPrice),
+		relayer.WithGasLimit(relayerGasLimit),
+		relayer.WithAddressPrefix(prefix),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := c.Balance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !balance.IsZero() {
+		return c, nil
+	}
+
+	funds := wc.RelayerFunds
+	if funds == "" {
+		funds = sdk.NewInt64Coin(staked.Denom, defaultRelayerFunds).String()
+	}
+	address := account.Address(prefix)
+
+	txHash, err := commands.BankSend(ctx, validator.Name, address, funds)
+	if err != nil {
+		return nil, fmt.Errorf("無法向中繼器帳戶轉入代幣: %w", err)
+	}
+	if err := commands.WaitTx(ctx, txHash, time.Second, 30); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(w.out, "💸 已從 %q 向中繼器帳戶 %s 轉入 %s\n", validator.Name, address, funds)
+	return c, nil
+}
+
+// removeRelayerPath 從中繼器配置中刪除路徑。
+func removeRelayerPath(id string) error {
+	conf, err := relayerconfig.Get()
+	if err != nil {
+		return err
+	}
+
+	paths := conf.Paths[:0]
+	for _, p := range conf.Paths {
+		if p.ID != id {
+			paths = append(paths, p)
+		}
+	}
+	conf.Paths = paths
+
+	return relayerconfig.Save(conf)
+}
+
+// removeRelayerChain 在中繼器配置中 ID 為 id 的鏈的 RPC 地址不是 rpcAddress 時刪除該鏈。
+func removeRelayerChain(id, rpcAddress string) error {
+	conf, err := relayerconfig.Get()
+	if err != nil {
+		return err
+	}
+
+	chains := conf.Chains[:0]
+	for _, c := range conf.Chains {
+		if c.ID != id || c.RPCAddress == strings.TrimSuffix(xurl.HTTPEnsurePort(rpcAddress), "/") {
+			chains = append(chains, c)
+		}
+	}
+	conf.Chains = chains
+
+	return relayerconfig.Save(conf)
+}
@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ignite-hq/cli/ignite/pkg/cache"
+	"github.com/ignite-hq/cli/ignite/pkg/localfs"
+	"github.com/ignite-hq/cli/ignite/services/chain"
+)
+
+// cacheDir 是工作區數據目錄中保存每條鏈的緩存的目錄。
+const cacheDir = "cache"
+
+type serveOptions struct {
+	forceReset bool
+	resetOnce  bool
+	clearCache bool
+}
+
+// ServeOption 配置工作區的服務。
+type ServeOption func(*serveOptions)
+
+// ServeForceReset 在啟動和每次源代碼修改時重置所有鏈的狀態。
+func ServeForceReset() ServeOption {
+	return func(o *serveOptions) {
+		o.forceReset = true
+	}
+}
+
+// ServeResetOnce 在啟動時重置所有鏈的狀態，中繼器路徑隨之重新創建。
+func ServeResetOnce() ServeOption {
+	return func(o *serveOptions) {
+		o.resetOnce = true
+	}
+}
+
+// ServeClearCache 在啟動前清除每條鏈的緩存。
+func ServeClearCache() ServeOption {
+	return func(o *serveOptions) {
+		o.clearCache = true
+	}
+}
+
+// Serve 服務工作區中的所有鏈，在所有鏈啟動後連接它們之間的路徑並開始中繼，直到 ctx 被取消。
+// 任何一條鏈的狀態被重置後，中繼器會重新創建與該鏈相連的路徑。
+func (w *Workspace) Serve(ctx context.Context, options ...ServeOption) error {
+	var so serveOptions
+	for _, apply := range options {
+		apply(&so)
+	}
+
+	var chainServeOptions []chain.ServeOption
+	if so.forceReset {
+		chainServeOptions = append(chainServeOptions, chain.ServeForceReset())
+	}
+	if so.resetOnce {
+		chainServeOptions = append(chainServeOptions, chain.ServeResetOnce())
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	reloadTriggers := w.reloadTriggers(ctx)
+
+	for _, wc := range w.chains {
+		wc := wc
+
+		serveOptions := chainServeOptions
+		if reload, ok := reloadTriggers[wc.Name]; ok {
+			serveOptions = append([]chain.ServeOption{chain.ServeReloadTrigger(reload)}, chainServeOptions...)
+		}
+
+		// 每條鏈使用自己的緩存，避免鏈之間的源代碼和配置校驗和相互覆蓋
+		cacheStorage, err := cache.NewStorage(filepath.Join(w.dataPath, cacheDir, wc.Name+".db"))
+		if err != nil {
+			return err
+		}
+		if so.clearCache {
+			if err := cacheStorage.Clear(); err != nil {
+				return err
+			}
+		}
+
+		g.Go(func() error {
+			return wc.chain.Serve(ctx, cacheStorage, serveOptions...)
+		})
+	}
+
+	g.Go(func() error {
+		return w.relay(ctx)
+	})
+
+	return g.Wait()
+}
+
+// reloadTriggers 返回啟用了 build.watch.manual 的鏈的手動重新加載觸發器，按鏈名保存。
+// 標準輸入只被讀取一次，每次按回車鍵或收到 SIGHUP 信號時所有這些鏈都會重新構建，
+// 以免鏈之間爭奪標準輸入的行。
+func (w *Workspace) reloadTriggers(ctx context.Context) map[string]<-chan struct{} {
+	triggers := make(map[string]<-chan struct{})
+	var outs []chan<- struct{}
+	for _, wc := range w.chains {
+		if conf, err := wc.chain.Config(); err == nil && conf.Build.Watch.Manual {
+			reload := make(chan struct{}, 1)
+			triggers[wc.Name] = reload
+			outs = append(outs, reload)
+		}
+	}
+
+	if len(outs) > 0 {
+		go broadcastReload(ctx, localfs.ReloadTrigger(ctx, os.Stdin), outs)
+	}
+
+	return triggers
+}
+
+// broadcastReload 將 in 收到的每次重新加載轉發到所有 outs。
+// 鏈正在重新構建時不會阻塞其他鏈，未處理的重新加載會合併為一次。
+func broadcastReload(ctx context.Context, in <-chan struct{}, outs []chan<- struct{}) {
+	for {
+		select {
+		case <-in:
+			for _, out := range outs {
+				select {
+				case out <- struct{}{}:
+				default:
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
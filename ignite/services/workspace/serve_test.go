@@ -0,0 +1,38 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		in   = make(chan struct{})
+		out1 = make(chan struct{}, 1)
+		out2 = make(chan struct{}, 1)
+	)
+	go broadcastReload(ctx, in, []chan<- struct{}{out1, out2})
+
+	// every chain is reloaded by a single trigger.
+	in <- struct{}{}
+	for _, out := range []chan struct{}{out1, out2} {
+		select {
+		case <-out:
+		case <-time.After(time.Second):
+			t.Fatal("reload was not forwarded")
+		}
+	}
+
+	// chains that are still rebuilding don't block the trigger, their pending reloads are merged.
+	for i := 0; i < 3; i++ {
+		in <- struct{}{}
+	}
+	require.Len(t, out1, 1)
+	require.Len(t, out2, 1)
+}
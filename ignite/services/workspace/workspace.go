@@ -0,0 +1,221 @@
+// Package workspace 在同一個工作區中服務多條鏈，並通過 IBC 中繼器將它們連接起來。
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/checksum"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite-hq/cli/ignite/pkg/lineprefixer"
+	"github.com/ignite-hq/cli/ignite/pkg/prefixgen"
+	"github.com/ignite-hq/cli/ignite/pkg/xfilepath"
+	"github.com/ignite-hq/cli/ignite/services/chain"
+)
+
+// workspacesPath 是保存工作區狀態和緩存的目錄。
+var workspacesPath = xfilepath.Join(
+	chainconfig.ConfigDirPath,
+	xfilepath.Path("workspaces"),
+)
+
+// prefixColors 是鏈日誌前綴輪流使用的顏色。
+var prefixColors = []uint8{39, 208, 141, 42, 198, 226}
+
+// Workspace 是一組一起服務並通過 IBC 連接的鏈。
+type Workspace struct {
+	conf Config
+
+	// chains 與 conf.Chains 的順序相同。
+	chains []workspaceChain
+
+	// ca 保存中繼器帳戶。
+	ca cosmosaccount.Registry
+
+	// dataPath 是保存工作區狀態和鏈緩存的目錄。
+	dataPath string
+
+	logLevel     chain.LogLvl
+	chainOptions []chain.Option
+	out          io.Writer
+}
+
+// workspaceChain 是工作區中的一條鏈。
+type workspaceChain struct {
+	Chain
+	chain *chain.Chain
+	id    string
+}
+
+// Option 配置工作區。
+type Option func(*Workspace)
+
+// LogLevel 設置鏈的日誌級別。
+func LogLevel(level chain.LogLvl) Option {
+	return func(w *Workspace) {
+		w.logLevel = level
+	}
+}
+
+// ChainOptions 添加應用於工作區中每條鏈的選項。
+func ChainOptions(options ...chain.Option) Option {
+	return func(w *Workspace) {
+		w.chainOptions = append(w.chainOptions, options...)
+	}
+}
+
+// Output 設置工作區和鏈日誌的輸出目標，默認為標準輸出。
+func Output(out io.Writer) Option {
+	return func(w *Workspace) {
+		w.out = out
+	}
+}
+
+// New 從位於 path 的工作區文件創建工作區，ca 保存中繼器帳戶。
+func New(path string, ca cosmosaccount.Registry, options ...Option) (*Workspace, error) {
+	conf, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	workspaces, err := workspacesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{
+		conf:     conf,
+		ca:       ca,
+		dataPath: filepath.Join(workspaces, checksum.Strings(absPath)),
+		logLevel: chain.LogRegular,
+		out:      os.Stdout,
+	}
+	for _, apply := range options {
+		apply(w)
+	}
+
+	ids := make(map[string]string)
+	homes := make(map[string]string)
+	for i, wc := range conf.Chains {
+		c, err := w.newChain(i, wc)
+		if err != nil {
+			return nil, fmt.Errorf("鏈 %q: %w", wc.Name, err)
+		}
+
+		id, err := c.ID()
+		if err != nil {
+			return nil, err
+		}
+		if name, ok := ids[id]; ok {
+			return nil, &ValidationError{fmt.Sprintf("鏈 %q 和 %q 的鏈 ID 都是 %q", name, wc.Name, id)}
+		}
+		ids[id] = wc.Name
+
+		home, err := c.Home()
+		if err != nil {
+			return nil, err
+		}
+		if home, err = filepath.Abs(home); err != nil {
+			return nil, err
+		}
+		if name, ok := homes[home]; ok {
+			return nil, &ValidationError{fmt.Sprintf("鏈 %q 和 %q 的主目錄都是 %q", name, wc.Name, home)}
+		}
+		homes[home] = wc.Name
+
+		w.chains = append(w.chains, workspaceChain{
+			Chain: wc,
+			chain: c,
+			id:    id,
+		})
+	}
+
+	return w, nil
+}
+
+// newChain 創建工作區中的第 i 條鏈，其端口增加 i*port_offset，日誌以鏈名稱為前綴。
+func (w *Workspace) newChain(i int, wc Chain) (*chain.Chain, error) {
+	prefix := prefixgen.
+		New(wc.Name, prefixgen.SquareBrackets(), prefixgen.SpaceRight(), prefixgen.Color(prefixColors[i%len(prefixColors)])).
+		Gen()
+	prefixed := lineprefixer.NewWriter(w.out, func() string { return prefix })
+
+	offset := i * w.conf.PortOffset
+	options := append([]chain.Option{
+		chain.LogLevel(w.logLevel),
+		chain.Output(prefixed, prefixed),
+		chain.ConfigOverride(func(conf *chainconfig.Config) {
+			conf.Host = shiftHost(conf.Host, offset)
+			conf.Faucet = shiftFaucet(*conf, offset)
+		}),
+	}, w.chainOptions...)
+
+	if wc.Config != "" {
+		configPath := wc.Config
+		if !filepath.IsAbs(configPath) {
+			configPath = filepath.Join(wc.Path, configPath)
+		}
+		options = append(options, chain.ConfigFile(configPath))
+	}
+	if wc.Home != "" {
+		options = append(options, chain.HomePath(wc.Home))
+	}
+
+	return chain.New(wc.Path, options...)
+}
+
+// shiftHost 將 host 中所有地址的端口增加 offset。
+func shiftHost(host chainconfig.Host, offset int) chainconfig.Host {
+	host.RPC = shiftPort(host.RPC, offset)
+	host.P2P = shiftPort(host.P2P, offset)
+	host.Prof = shiftPort(host.Prof, offset)
+	host.GRPC = shiftPort(host.GRPC, offset)
+	host.GRPCWeb = shiftPort(host.GRPCWeb, offset)
+	host.API = shiftPort(host.API, offset)
+	host.Explorer = shiftPort(host.Explorer, offset)
+	host.Health = shiftPort(host.Health, offset)
+	return host
+}
+
+// shiftFaucet 將 conf 中水龍頭地址的端口增加 offset。
+// 已棄用的 faucet.port 優先於 faucet.host，因此它被轉換為 faucet.host 後再增加端口。
+func shiftFaucet(conf chainconfig.Config, offset int) chainconfig.Faucet {
+	faucet := conf.Faucet
+	faucet.Host = shiftPort(chainconfig.FaucetHost(conf), offset)
+	faucet.Port = 0
+	return faucet
+}
+
+// shiftPort 將地址的端口增加 offset，地址可以帶有協議，例如 tcp://0.0.0.0:26657。
+// 無法解析端口的地址保持不變，由配置驗證報告。
+func shiftPort(address string, offset int) string {
+	if address == "" || offset == 0 {
+		return address
+	}
+
+	scheme := ""
+	if i := strings.Index(address, "://"); i >= 0 {
+		scheme, address = address[:i+3], address[i+3:]
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return scheme + address
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return scheme + address
+	}
+
+	return scheme + net.JoinHostPort(host, strconv.Itoa(n+offset))
+}
@@ -0,0 +1,78 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite-hq/cli/ignite/chainconfig"
+	"github.com/ignite-hq/cli/ignite/pkg/cosmosaccount"
+)
+
+func TestShiftHost(t *testing.T) {
+	require.Equal(t, chainconfig.Host{
+		RPC:     "0.0.0.0:26677",
+		P2P:     "0.0.0.0:26676",
+		Prof:    "0.0.0.0:6080",
+		GRPC:    "0.0.0.0:9110",
+		GRPCWeb: "0.0.0.0:9111",
+		API:     "tcp://localhost:1337",
+	}, shiftHost(chainconfig.Host{
+		RPC:     "0.0.0.0:26657",
+		P2P:     "0.0.0.0:26656",
+		Prof:    "0.0.0.0:6060",
+		GRPC:    "0.0.0.0:9090",
+		GRPCWeb: "0.0.0.0:9091",
+		API:     "tcp://localhost:1317",
+	}, 20))
+
+	require.Equal(t, ":4510", shiftPort(":4500", 10))
+	require.Equal(t, "0.0.0.0", shiftPort("0.0.0.0", 10))
+	require.Equal(t, "0.0.0.0:4500", shiftPort("0.0.0.0:4500", 0))
+}
+
+func TestShiftFaucet(t *testing.T) {
+	var conf chainconfig.Config
+	conf.Faucet.Host = "0.0.0.0:4500"
+	require.Equal(t, "0.0.0.0:4510", shiftFaucet(conf, 10).Host)
+
+	// the deprecated port takes precedence over the host.
+	conf.Faucet.Port = 4600
+	faucet := shiftFaucet(conf, 10)
+	require.Equal(t, ":4610", faucet.Host)
+	require.Zero(t, faucet.Port)
+}
+
+const testChainConfig = `
+accounts:
+  - name: alice
+    coins: ["1000token"]
+validator:
+  name: alice
+  staked: "100token"
+`
+
+func TestNewDuplicateHome(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"mars", "venus"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0o755))
+		goMod := "module github.com/test/" + name + "\n\ngo 1.18\n\nrequire github.com/cosmos/cosmos-sdk v0.45.4\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "go.mod"), []byte(goMod), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "config.yml"), []byte(testChainConfig), 0o644))
+	}
+	path := filepath.Join(dir, "workspace.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+chains:
+  - path: mars
+    home: `+filepath.Join(dir, "home")+`
+  - path: venus
+    home: `+filepath.Join(dir, "home", ".")+`
+`), 0o644))
+
+	_, err := New(path, cosmosaccount.Registry{})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Contains(t, err.Error(), "主目錄")
+}